@@ -0,0 +1,285 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/genewoo/joker/internal/deck"
+	"github.com/genewoo/joker/internal/guandan"
+	"github.com/spf13/cobra"
+)
+
+// NewGuandanCmd creates a new guandan game command
+func NewGuandanCmd(options *GuandanOptions) *cobra.Command {
+	guandanCmd := &cobra.Command{
+		Use:   "guandan",
+		Short: "Guandan (掼蛋) game commands",
+		Long: `Guandan is played by 4 players in 2 teams, A (players 1 and 3) and B (players 2 and 4),
+with 2 decks of cards and jokers.`,
+	}
+
+	guandanCmd.AddCommand(createGuandanDealCmd(options), createGuandanEvalCmd(options), createGuandanSimulateCmd(options),
+		createGuandanTrackerCmd(options))
+	return guandanCmd
+}
+
+func createGuandanDealCmd(options *GuandanOptions) *cobra.Command {
+	dealCmd := &cobra.Command{
+		Use:   "deal",
+		Short: "Deal a Guandan deal and pay the tributes",
+		Long: `Deal 27 cards to each of the 4 players after a deal that finished in the given ranking,
+then pay the tributes of the losing players. The deal is played at the level of the team of the
+first player of the ranking, and hands are shown from the highest card in that level's order.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ranking, err := guandan.ParseRanking(options.LastRanking)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			levels, err := parseTeamLevels(options.TeamLevels)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			game := guandan.NewGame(ranking, levels)
+			game.SetSource(options.source())
+			game.DealCards()
+			paid := game.SwapCards()
+
+			fmt.Printf("Dealing at level %s (team A: %s, team B: %s, last ranking %s, seed %d):\n",
+				game.Level(), levels[0], levels[1], formatRanking(ranking), options.Seed)
+
+			fmt.Println("\nTribute:")
+			if !paid {
+				fmt.Println("None, the paying players hold both red jokers")
+			}
+			for _, tribute := range game.Tributes() {
+				fmt.Printf("Player %d gives %s to player %d, who returns %s\n",
+					tribute.Giver, tribute.Card, tribute.Receiver, tribute.Return)
+			}
+			fmt.Printf("Player %d deals and leads the first trick\n", game.Dealer())
+
+			for seat := 1; seat <= 4; seat++ {
+				hand := game.Hand(seat)
+				hand.Sort()
+				fmt.Printf("\nPlayer %d (team %s, %d cards):\n", seat, [2]string{"A", "B"}[(seat-1)%2], hand.Count())
+				for _, card := range hand.Cards {
+					fmt.Printf("%s ", card.String())
+				}
+				fmt.Println()
+			}
+		},
+	}
+
+	dealCmd.Flags().StringVarP(&options.LastRanking, "ranking", "r", "1,2,3,4", "Players in the order they finished the last deal")
+	dealCmd.Flags().StringVarP(&options.TeamLevels, "levels", "l", "2,2", "Levels of teams A and B")
+	dealCmd.Flags().Int64Var(&options.Seed, "seed", 0, seedHelp)
+
+	return dealCmd
+}
+
+func createGuandanEvalCmd(options *GuandanOptions) *cobra.Command {
+	evalCmd := &cobra.Command{
+		Use:   "eval <cards> [<cards to beat them>]",
+		Short: "Classify a Guandan play, or compare two plays",
+		Long: `Classify the cards as a Guandan combination at the given level, with the hearts of the level
+playing as wild cards. Given a second play, tell whether it beats the first one.
+Cards are written as in "As Kh 10d", "A♠ K♥ T♦"; jokers are written "BJ" and "RJ".`,
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			level, err := guandan.ParseLevel(options.Level)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			combiner := guandan.NewCombiner(level)
+
+			strengths := make([]guandan.CombinationStrength, len(args))
+			for i, arg := range args {
+				cards, err := deck.ParseCards(arg)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				if len(cards) == 0 {
+					fmt.Println("Error: no cards given")
+					os.Exit(1)
+				}
+				strengths[i] = combiner.EvaluateCombination(cards)
+				fmt.Printf("%s: %s\n", formatCards(cards), describeCombination(strengths[i]))
+			}
+
+			if len(strengths) == 2 {
+				if combiner.Beats(strengths[0], strengths[1]) {
+					fmt.Println("The second play beats the first")
+				} else {
+					fmt.Println("The second play does not beat the first")
+				}
+			}
+		},
+	}
+
+	evalCmd.Flags().StringVarP(&options.Level, "level", "l", "2", "Level of the deal")
+
+	return evalCmd
+}
+
+func createGuandanSimulateCmd(options *GuandanOptions) *cobra.Command {
+	simulateCmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Play Guandan deals between bots and report statistics",
+		Long: `Play complete deals between bots, each from a random last ranking and random team levels:
+deal, pay the tributes, play the deal and advance the winners' level. Report the teams' win rates,
+the levels they gained, how often bombs were played, the tributes paid and the length of the deals.
+The heuristic bot plays every seat, unless the greedy bot plays the seats of team A or B.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if options.Deals < 1 {
+				fmt.Println("Error: the number of deals must be at least 1")
+				os.Exit(1)
+			}
+
+			simulator := guandan.NewSimulator()
+			switch strings.ToUpper(options.Greedy) {
+			case "":
+			case "A":
+				simulator.SetStrategy(1, guandan.GreedyBot{})
+				simulator.SetStrategy(3, guandan.GreedyBot{})
+			case "B":
+				simulator.SetStrategy(2, guandan.GreedyBot{})
+				simulator.SetStrategy(4, guandan.GreedyBot{})
+			default:
+				fmt.Printf("Error: invalid team %q: must be A or B\n", options.Greedy)
+				os.Exit(1)
+			}
+			if options.Workers > 0 {
+				simulator.SetWorkers(options.Workers)
+			}
+			simulator.Seed(options.seed())
+
+			stats, err := simulator.Run(options.Deals)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Simulated %d deals (seed %d):\n%s\n", options.Deals, options.Seed, stats)
+		},
+	}
+
+	simulateCmd.Flags().IntVarP(&options.Deals, "deals", "n", 1000, "Number of deals to simulate")
+	simulateCmd.Flags().IntVarP(&options.Workers, "workers", "w", 0, "Number of deals simulated at once (0 uses every CPU)")
+	simulateCmd.Flags().StringVar(&options.Greedy, "greedy", "", "Team played by the greedy bot, A or B (default none)")
+	simulateCmd.Flags().Int64Var(&options.Seed, "seed", 0, seedHelp)
+
+	return simulateCmd
+}
+
+func createGuandanTrackerCmd(options *GuandanOptions) *cobra.Command {
+	trackerCmd := &cobra.Command{
+		Use:   "tracker",
+		Short: "Count the cards a Guandan player has not seen",
+		Long: `Deal a Guandan deal as the deal command does, let bots play the given number of turns, then
+count the cards the player at the seat has not seen: the cards of the two decks neither in their
+hand nor played, by rank and suit, with the jokers and the wild heart level cards left.
+Counting along with the deal is also good memory practice.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ranking, err := guandan.ParseRanking(options.LastRanking)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			levels, err := parseTeamLevels(options.TeamLevels)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			if options.Seat < 1 || options.Seat > 4 {
+				fmt.Printf("Error: invalid seat %d: must be from 1 to 4\n", options.Seat)
+				os.Exit(1)
+			}
+
+			game := guandan.NewGame(ranking, levels)
+			game.SetSource(options.source())
+			for seat := 1; seat <= 4; seat++ {
+				game.SetStrategy(seat, guandan.HeuristicBot{})
+			}
+			game.DealCards()
+			game.SwapCards()
+			if err := game.StartPlay(game.Dealer()); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			for turn := 0; turn < options.Turns && !game.Finished(); turn++ {
+				if err := game.PlayTurn(); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			view := game.View(options.Seat)
+			fmt.Printf("Tracking player %d at level %s after %d turns (seed %d):\n",
+				options.Seat, view.Level, len(view.Plays), options.Seed)
+			fmt.Printf("\nHand (%d cards): %s\n", len(view.Hand), formatCards(view.Hand))
+			fmt.Printf("Played: %d cards\n", len(view.PlayedCards()))
+			for seat, left := range view.CardsLeft {
+				if seat+1 != options.Seat {
+					fmt.Printf("Player %d holds %d cards\n", seat+1, left)
+				}
+			}
+			fmt.Printf("\n%s\n", guandan.NewTracker(view))
+		},
+	}
+
+	trackerCmd.Flags().IntVarP(&options.Seat, "seat", "s", 1, "Seat of the player whose unseen cards are counted")
+	trackerCmd.Flags().IntVarP(&options.Turns, "turns", "t", 0, "Number of plays and passes the bots make first")
+	trackerCmd.Flags().StringVarP(&options.LastRanking, "ranking", "r", "1,2,3,4", "Players in the order they finished the last deal")
+	trackerCmd.Flags().StringVarP(&options.TeamLevels, "levels", "l", "2,2", "Levels of teams A and B")
+	trackerCmd.Flags().Int64Var(&options.Seed, "seed", 0, seedHelp)
+
+	return trackerCmd
+}
+
+// parseTeamLevels parses the levels of teams A and B, such as "2,5"
+func parseTeamLevels(s string) ([2]string, error) {
+	var levels [2]string
+	fields := strings.Split(s, ",")
+	if len(fields) != 2 {
+		return levels, fmt.Errorf("invalid levels %q: must give the levels of teams A and B, such as 2,5", s)
+	}
+	for i, field := range fields {
+		level, err := guandan.ParseLevel(field)
+		if err != nil {
+			return levels, err
+		}
+		levels[i] = level
+	}
+	return levels, nil
+}
+
+// formatRanking formats a ranking as "1-3-2-4"
+func formatRanking(ranking [4]int) string {
+	seats := make([]string, len(ranking))
+	for i, seat := range ranking {
+		seats[i] = fmt.Sprint(seat)
+	}
+	return strings.Join(seats, "-")
+}
+
+// formatCards formats cards separated by spaces
+func formatCards(cards []*deck.Card) string {
+	names := make([]string, len(cards))
+	for i, card := range cards {
+		names[i] = card.String()
+	}
+	return strings.Join(names, " ")
+}
+
+// describeCombination names the combination and the cards its wild cards stand in for
+func describeCombination(strength guandan.CombinationStrength) string {
+	description := strength.Type.String()
+	for _, s := range strength.Substitutions {
+		description += fmt.Sprintf(", %s as %s", s.Wild, s.As)
+	}
+	return description
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -22,21 +23,23 @@ func NewHoldemCmd(options *HoldemOptions) *cobra.Command {
 	}
 
 	dealCmd := createDealCmd(options, &gameTypeStr)
-	eqCmd := createEquityCmd(options)
+	eqCmd := createEquityCmd(options, &gameTypeStr)
+	replayCmd := createReplayCmd(options)
 
-	holdemCmd.AddCommand(dealCmd, eqCmd)
+	holdemCmd.AddCommand(dealCmd, eqCmd, replayCmd)
 	return holdemCmd
 }
 
-func createDealCmd(options *HoldemOptions, gameTypeStr *string) *cobra.Command {
-
-	// Create help message for game types using AllGameTypes
+// gameTypeHelp builds the help message for the game type flag using AllGameTypes
+func gameTypeHelp() string {
 	gameTypes := make([]string, len(holdem.AllGameTypes()))
 	for i, gt := range holdem.AllGameTypes() {
 		gameTypes[i] = gt.String()
 	}
-	gameTypeHelp := fmt.Sprintf("Game type (%s)", strings.Join(gameTypes, ", "))
+	return fmt.Sprintf("Game type (%s)", strings.Join(gameTypes, ", "))
+}
 
+func createDealCmd(options *HoldemOptions, gameTypeStr *string) *cobra.Command {
 	dealCmd := &cobra.Command{
 		Use:   "deal",
 		Short: "Deal cards in Hold'em style",
@@ -51,6 +54,7 @@ func createDealCmd(options *HoldemOptions, gameTypeStr *string) *cobra.Command {
 
 			// Create new holdem game
 			game := holdem.NewGame(options.GameType, options.NumPlayers)
+			game.SetSource(options.source())
 
 			// Deal initial cards
 			if err := game.StartHand(); err != nil {
@@ -59,7 +63,7 @@ func createDealCmd(options *HoldemOptions, gameTypeStr *string) *cobra.Command {
 			}
 
 			// Print player hands
-			fmt.Printf("Dealing %d cards to %d players:\n", options.NumCardsPerPlayer, options.NumPlayers)
+			fmt.Printf("Dealing %d cards to %d players (seed %d):\n", options.NumCardsPerPlayer, options.NumPlayers, options.Seed)
 			for i, player := range game.Players {
 				fmt.Printf("\nPlayer %d:\n", i+1)
 				for _, card := range player.Cards {
@@ -97,19 +101,52 @@ func createDealCmd(options *HoldemOptions, gameTypeStr *string) *cobra.Command {
 
 	dealCmd.Flags().IntVarP(&options.NumPlayers, "players", "p", 2, "Number of players")
 	dealCmd.Flags().IntVarP(&options.NumCardsPerPlayer, "numberofcards", "n", 2, "Number of cards per player")
-	dealCmd.Flags().StringVarP(gameTypeStr, "type", "t", holdem.Texas.String(), gameTypeHelp)
+	dealCmd.Flags().StringVarP(gameTypeStr, "type", "t", holdem.Texas.String(), gameTypeHelp())
+	dealCmd.Flags().Int64Var(&options.Seed, "seed", 0, seedHelp)
 
 	return dealCmd
 }
 
-func createEquityCmd(options *HoldemOptions) *cobra.Command {
+func createEquityCmd(options *HoldemOptions, gameTypeStr *string) *cobra.Command {
 	eqCmd := &cobra.Command{
 		Use:   "eq",
 		Short: "Calculate equity for players",
-		Long: `Calculate equity (winning probability) for each player in a Texas Hold'em game.
-Example card format: "As Kh" for Ace of spades and King of hearts.
-Use "♠" for spades, "♥" for hearts, "♦" for diamonds, "♣" for clubs.`,
+		Long: `Calculate equity (winning probability) for each player in a Hold'em game.
+Omaha players must be given 4 hole cards each.
+Use --range instead of --cards to give each player a hand range such as "QQ+, AKs, A2s-A5s, KTo+, 76s";
+a combo can be weighted with a suffix like "AA:0.5".
+Cards are written as a value (2-10, T, J, Q, K, A) followed by a suit, either a letter
+(s, h, d, c) or a symbol (♠, ♥, ♦, ♣): "As Kh", "Td 10c" and "A♠ K♥" are all valid.
+A card may only be used once across all players and the board.`,
 		Run: func(cmd *cobra.Command, args []string) {
+			gameType, err := holdem.ParseGameType(*gameTypeStr)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			options.GameType = gameType
+			holeCards := gameType.HoleCards()
+
+			// Parse community cards if provided
+			community, err := holdem.ParseCards(gameType, options.CommunityCards)
+			if err != nil {
+				fmt.Printf("Error: Community cards: %v\n", err)
+				os.Exit(1)
+			}
+			if len(community) > 5 {
+				fmt.Println("Error: Maximum 5 community cards allowed")
+				os.Exit(1)
+			}
+			if err := deck.CheckDuplicates(community); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			if len(options.PlayerRanges) > 0 {
+				runRangeEquity(options, community)
+				return
+			}
+
 			// Parse player cards
 			if len(options.PlayerCards) == 0 {
 				fmt.Println("Error: At least one player's cards must be specified")
@@ -118,40 +155,35 @@ Use "♠" for spades, "♥" for hearts, "♦" for diamonds, "♣" for clubs.`,
 
 			// Convert player cards strings to Card objects
 			players := make([][]*deck.Card, len(options.PlayerCards))
+			allCards := append([]*deck.Card{}, community...)
 			for i, cardStr := range options.PlayerCards {
-				cards := strings.Fields(cardStr)
-				if len(cards) != 2 {
-					fmt.Printf("Error: Player %d must have exactly 2 cards, got: %s\n", i+1, cardStr)
+				cards, err := holdem.ParseCards(gameType, cardStr)
+				if err != nil {
+					fmt.Printf("Error: Player %d: %v\n", i+1, err)
 					os.Exit(1)
 				}
-
-				players[i] = make([]*deck.Card, 2)
-				for j, card := range cards {
-					value := card[:len(card)-1]
-					suit := card[len(card)-1:]
-					players[i][j] = deck.NewCard(value, suit)
-				}
-			}
-
-			// Parse community cards if provided
-			var community []*deck.Card
-			if options.CommunityCards != "" {
-				cards := strings.Fields(options.CommunityCards)
-				if len(cards) > 5 {
-					fmt.Println("Error: Maximum 5 community cards allowed")
+				if len(cards) != holeCards {
+					fmt.Printf("Error: Player %d must have exactly %d cards, got: %s\n", i+1, holeCards, cardStr)
 					os.Exit(1)
 				}
-
-				for _, card := range cards {
-					value := card[:len(card)-1]
-					suit := card[len(card)-1:]
-					community = append(community, deck.NewCard(value, suit))
-				}
+				players[i] = cards
+				allCards = append(allCards, cards...)
+			}
+			if err := deck.CheckDuplicates(allCards); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
 			}
 
 			// Create calculator and calculate probabilities
 			calc := holdem.NewWinningCalculator(players, options.NumSimulations, holdem.NewDefaultHandRanker(), community...)
-			probabilities := calc.CalculateWinProbabilities()
+			calc.SetGameType(options.GameType)
+			calc.SetSource(options.source())
+			var probabilities []float64
+			if options.Exact {
+				probabilities = calc.CalculateExactProbabilities()
+			} else {
+				probabilities = calc.CalculateWinProbabilities()
+			}
 
 			// Display results
 			fmt.Println("\nEquity calculation results:")
@@ -162,13 +194,136 @@ Use "♠" for spades, "♥" for hearts, "♦" for diamonds, "♣" for clubs.`,
 				fmt.Printf("\nCommunity cards: %s\n", options.CommunityCards)
 			}
 			fmt.Printf("Tie probability: %.2f%%\n", probabilities[len(players)]*100)
+			fmt.Printf("Seed: %d\n", options.Seed)
 		},
 	}
 
-	eqCmd.Flags().StringSliceVarP(&options.PlayerCards, "cards", "c", []string{}, "Player hole cards (e.g. \"As Kh\" \"Jd Tc\")")
-	eqCmd.Flags().StringVarP(&options.CommunityCards, "board", "b", "", "Community cards (e.g. \"Ah Kd Qc\")")
+	eqCmd.Flags().StringSliceVarP(&options.PlayerCards, "cards", "c", []string{}, "Player hole cards (e.g. \"As Kh\" \"Jd Tc\" \"Q♠ Q♥\")")
+	eqCmd.Flags().StringVarP(&options.CommunityCards, "board", "b", "", "Community cards (e.g. \"Ah Kd Qc\" or \"A♥ K♦ Q♣\")")
 	eqCmd.Flags().IntVarP(&options.NumSimulations, "simulations", "s", 10000, "Number of Monte Carlo simulations")
-	eqCmd.MarkFlagRequired("cards")
+	eqCmd.Flags().BoolVarP(&options.Exact, "exact", "e", false, "Evaluate every remaining board instead of sampling (not with --range)")
+	eqCmd.Flags().StringVarP(gameTypeStr, "type", "t", holdem.Texas.String(), gameTypeHelp())
+	eqCmd.Flags().Int64Var(&options.Seed, "seed", 0, seedHelp)
+	eqCmd.Flags().StringArrayVarP(&options.PlayerRanges, "range", "r", []string{}, "Player hand ranges, one flag per player (e.g. \"QQ+, AKs, A2s-A5s\" \"KTo+, 76s, AA:0.5\")")
+	eqCmd.MarkFlagsOneRequired("cards", "range")
+	eqCmd.MarkFlagsMutuallyExclusive("cards", "range")
+	eqCmd.MarkFlagsMutuallyExclusive("exact", "range")
 
 	return eqCmd
 }
+
+// runRangeEquity calculates and prints the equity of each player's hand range
+func runRangeEquity(options *HoldemOptions, community []*deck.Card) {
+	ranges := make([]*holdem.Range, len(options.PlayerRanges))
+	for i, notation := range options.PlayerRanges {
+		r, err := holdem.ParseRange(notation)
+		if err != nil {
+			fmt.Printf("Error: Player %d range: %v\n", i+1, err)
+			os.Exit(1)
+		}
+		ranges[i] = r
+	}
+
+	calc := holdem.NewWinningCalculator(nil, options.NumSimulations, holdem.NewDefaultHandRanker(), community...)
+	calc.SetGameType(options.GameType)
+	calc.SetSource(options.source())
+	probabilities, err := calc.CalculateRangeEquities(ranges)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("\nEquity calculation results:")
+	for i, r := range ranges {
+		fmt.Printf("Player %d (%s, %.0f combos): %.2f%%\n", i+1, options.PlayerRanges[i], r.Size(), probabilities[i]*100)
+	}
+	if len(community) > 0 {
+		fmt.Printf("\nCommunity cards: %s\n", options.CommunityCards)
+	}
+	fmt.Printf("Tie probability: %.2f%%\n", probabilities[len(ranges)]*100)
+	fmt.Printf("Seed: %d\n", options.Seed)
+}
+
+func createReplayCmd(options *HoldemOptions) *cobra.Command {
+	replayCmd := &cobra.Command{
+		Use:   "replay <history.json>",
+		Short: "Replay a recorded hand street by street",
+		Long: `Replay a hand history exported as JSON, street by street, showing each player's
+equity at the start of every street, the actions taken and the showdown.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				fmt.Printf("Error reading hand history: %v\n", err)
+				os.Exit(1)
+			}
+			history, err := holdem.ParseHandHistory(data)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			replayer, err := holdem.NewReplayer(history, options.NumSimulations, holdem.NewDefaultHandRanker())
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			replayer.SetSource(options.source())
+
+			for i, seat := range history.Seats {
+				fmt.Printf("Seat %d: %s (%d chips)\n", i+1, strings.Join(seat.Cards, " "), seat.Stack)
+			}
+			for {
+				step, err := replayer.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				printReplayStep(step)
+			}
+		},
+	}
+
+	replayCmd.Flags().IntVarP(&options.NumSimulations, "simulations", "s", 10000, "Number of Monte Carlo simulations per street")
+	replayCmd.Flags().Int64Var(&options.Seed, "seed", 0, seedHelp)
+
+	return replayCmd
+}
+
+// printReplayStep prints the board, equities and actions of a replayed street
+func printReplayStep(step *holdem.ReplayStep) {
+	fmt.Printf("\n%s", strings.ToUpper(step.Street.String()))
+	if len(step.Board) > 0 {
+		board := make([]string, len(step.Board))
+		for i, card := range step.Board {
+			board[i] = card.String()
+		}
+		fmt.Printf(": %s", strings.Join(board, " "))
+	}
+	fmt.Println()
+
+	seats := len(step.Equities) - 1
+	for i := 0; i < seats; i++ {
+		fmt.Printf("  Seat %d equity: %.2f%%\n", i+1, step.Equities[i]*100)
+	}
+	fmt.Printf("  Tie probability: %.2f%%\n", step.Equities[seats]*100)
+	for _, action := range step.Actions {
+		if action.Amount > 0 {
+			fmt.Printf("  Seat %d %s %d\n", action.Player+1, action.Type, action.Amount)
+		} else {
+			fmt.Printf("  Seat %d %s\n", action.Player+1, action.Type)
+		}
+	}
+	fmt.Printf("  Pot: %d\n", step.Pot)
+
+	if step.Result != nil {
+		fmt.Println("\nSHOWDOWN")
+		for i, payout := range step.Result.Payouts {
+			if payout > 0 {
+				fmt.Printf("  Seat %d wins %d\n", i+1, payout)
+			}
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package commands
 
 import (
+	"math/rand"
+	"time"
+
 	"github.com/genewoo/joker/internal/holdem"
 )
 
@@ -8,6 +11,24 @@ import (
 type CommonOptions struct {
 	NumPlayers        int
 	NumCardsPerPlayer int
+	Seed              int64 // Seed for the random source; 0 picks one from the current time
+}
+
+// seedHelp is the help message of the seed flag
+const seedHelp = "Seed for shuffling, to reproduce a deal or simulation (0 picks a random seed)"
+
+// seed returns the seed option, first picking a seed from the current time when
+// none is set so that it can be reported
+func (o *CommonOptions) seed() int64 {
+	if o.Seed == 0 {
+		o.Seed = time.Now().UnixNano()
+	}
+	return o.Seed
+}
+
+// source returns the random source for the seed option
+func (o *CommonOptions) source() rand.Source {
+	return rand.NewSource(o.seed())
 }
 
 // StandardOptions contains options specific to standard game commands
@@ -24,5 +45,25 @@ type HoldemOptions struct {
 	GameType       holdem.GameType
 	NumSimulations int
 	PlayerCards    []string
+	PlayerRanges   []string
 	CommunityCards string
+	Exact          bool
+}
+
+// GuandanOptions contains options specific to guandan game commands
+type GuandanOptions struct {
+	CommonOptions
+	LastRanking string // Players in the order they finished the last deal, such as "1,3,2,4"
+	TeamLevels  string // Levels of teams A and B, such as "2,5"
+	Level       string // Level combinations are evaluated at
+	Deals       int    // Number of deals to simulate
+	Workers     int    // Number of deals simulated at once; 0 uses every CPU
+	Greedy      string // Team whose seats the greedy bot plays in simulations, "" for none
+	Seat        int    // Seat whose unseen cards are tracked
+	Turns       int    // Number of turns the bots play before the cards are tracked
+}
+
+// StareEyesOptions contains options specific to stareeyes game commands
+type StareEyesOptions struct {
+	CommonOptions
 }
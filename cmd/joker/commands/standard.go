@@ -48,11 +48,12 @@ func NewStandardCmd(options *StandardOptions) *cobra.Command {
 			}
 
 			// Shuffle the deck
+			d.SetSource(options.source())
 			d.Shuffle()
 
 			// Deal cards to each player
-			fmt.Printf("Dealing %d cards to %d players (keeping %d cards):\n",
-				options.NumCardsPerPlayer, options.NumPlayers, options.KeepCards)
+			fmt.Printf("Dealing %d cards to %d players (keeping %d cards, seed %d):\n",
+				options.NumCardsPerPlayer, options.NumPlayers, options.KeepCards, options.Seed)
 			cards := d.Cards
 			currentCard := 0
 
@@ -84,6 +85,7 @@ func NewStandardCmd(options *StandardOptions) *cobra.Command {
 	dealCmd.Flags().BoolVarP(&options.IncludeJokers, "joker", "j", true, "Include jokers")
 	dealCmd.Flags().IntVarP(&options.KeepCards, "keep", "k", 0, "Number of cards to keep")
 	dealCmd.Flags().IntVarP(&options.NumCardsPerPlayer, "numberofcards", "n", 0, "Number of cards per player")
+	dealCmd.Flags().Int64Var(&options.Seed, "seed", 0, seedHelp)
 
 	standardCmd.AddCommand(dealCmd)
 	return standardCmd
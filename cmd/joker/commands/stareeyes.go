@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/genewoo/joker/internal/deck"
+	"github.com/genewoo/joker/internal/stareeyes"
+	"github.com/spf13/cobra"
+)
+
+// NewStareEyesCmd creates a new stareeyes game command
+func NewStareEyesCmd(options *StareEyesOptions) *cobra.Command {
+	stareEyesCmd := &cobra.Command{
+		Use:   "stareeyes",
+		Short: "Stare-Eyes (瞪眼) game commands",
+		Long: `Stare-Eyes is a two-player shedding game with one deck and the jokers as wild cards:
+each play must beat the last one by exactly one rank, and the first player out of cards wins.`,
+	}
+
+	stareEyesCmd.AddCommand(createStareEyesDealCmd(options), createStareEyesEvalCmd())
+	return stareEyesCmd
+}
+
+func createStareEyesDealCmd(options *StareEyesOptions) *cobra.Command {
+	dealCmd := &cobra.Command{
+		Use:   "deal",
+		Short: "Deal 6 cards to player A and 5 to player B",
+		Run: func(cmd *cobra.Command, args []string) {
+			game := stareeyes.NewGame()
+			game.SetSource(options.source())
+			if err := game.Deal(); err != nil {
+				fmt.Printf("Error dealing cards: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Dealing Stare-Eyes (seed %d):\n", options.Seed)
+			for _, player := range []int{stareeyes.PlayerA, stareeyes.PlayerB} {
+				fmt.Printf("\nPlayer %c:\n", 'A'+player-1)
+				for _, card := range game.Hand(player).Cards {
+					fmt.Printf("%s ", card.String())
+				}
+				fmt.Println()
+			}
+			fmt.Printf("\nStock: %d cards\n", game.Stock())
+		},
+	}
+
+	dealCmd.Flags().Int64Var(&options.Seed, "seed", 0, seedHelp)
+
+	return dealCmd
+}
+
+func createStareEyesEvalCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "eval <cards> [<cards to beat them>]",
+		Short: "Classify a Stare-Eyes play, or compare two plays",
+		Long: `Classify the cards as a Stare-Eyes combination, with the jokers playing as wild cards.
+Given a second play, tell whether it beats the first one.
+Cards are written as in "As Kh 10d", "A♠ K♥ T♦"; jokers are written "BJ" and "RJ".`,
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			plays := make([][]stareeyes.Combination, len(args))
+			for i, arg := range args {
+				cards, err := deck.ParseCards(arg)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				plays[i] = stareeyes.Evaluate(cards)
+
+				description := stareeyes.InvalidCombination.String()
+				if len(plays[i]) > 0 {
+					description = describeStareEyes(plays[i][0])
+				}
+				fmt.Printf("%s: %s\n", formatCards(cards), description)
+			}
+
+			// The first play leads as its strongest combination, and the jokers of the
+			// second play stand in for the lowest one that beats it, as in a game
+			if len(plays) == 2 && len(plays[0]) > 0 {
+				for i := len(plays[1]) - 1; i >= 0; i-- {
+					if stareeyes.Beats(plays[0][0], plays[1][i]) {
+						fmt.Printf("The second play beats the first as %s\n", describeStareEyes(plays[1][i]))
+						return
+					}
+				}
+				fmt.Println("The second play does not beat the first")
+			}
+		},
+	}
+}
+
+// describeStareEyes names a combination and its highest card, such as "Straight to 7"
+func describeStareEyes(c stareeyes.Combination) string {
+	values := []string{"3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A", "2"}
+	value := values[c.Rank-3]
+	switch c.Type {
+	case stareeyes.Straight, stareeyes.PairStraight:
+		return fmt.Sprintf("%s to %s", c.Type, value)
+	case stareeyes.Single:
+		return fmt.Sprintf("%s %s", c.Type, value)
+	}
+	return fmt.Sprintf("%s of %s", c.Type, value)
+}
@@ -37,10 +37,26 @@ and card dealings for different types of poker games.`,
 		NumSimulations: 10000,
 	}
 
+	guandanOpts := &commands.GuandanOptions{
+		CommonOptions: commands.CommonOptions{
+			NumPlayers:        4,
+			NumCardsPerPlayer: 27,
+		},
+	}
+
+	stareEyesOpts := &commands.StareEyesOptions{
+		CommonOptions: commands.CommonOptions{
+			NumPlayers:        2,
+			NumCardsPerPlayer: 5,
+		},
+	}
+
 	// Add commands
 	rootCmd.AddCommand(
 		commands.NewStandardCmd(standardOpts),
 		commands.NewHoldemCmd(holdemOpts),
+		commands.NewGuandanCmd(guandanOpts),
+		commands.NewStareEyesCmd(stareEyesOpts),
 	)
 
 	if err := rootCmd.Execute(); err != nil {
@@ -25,6 +25,7 @@ func NewCard(value, suit string) *Card {
 // Deck represents a collection of cards
 type Deck struct {
 	Cards []*Card
+	rng   *rand.Rand // Source of randomness for shuffling; nil seeds from the current time
 }
 
 // Count returns the number of remaining cards in the deck
@@ -77,10 +78,30 @@ func NewDeckWithJokers(masks ...string) *Deck {
 	return newDeck(true, masks...)
 }
 
+// SetSource sets the source of randomness used by Shuffle and DrawWithLimitHands,
+// so that a source in the same state reproduces the same order.
+// A nil src restores seeding each shuffle with the current time.
+func (d *Deck) SetSource(src rand.Source) {
+	if src == nil {
+		d.rng = nil
+		return
+	}
+	d.rng = rand.New(src)
+}
+
+// Seed makes the deck's shuffles reproducible from the given seed
+func (d *Deck) Seed(seed int64) {
+	d.SetSource(rand.NewSource(seed))
+}
+
 // Shuffle randomizes the order of cards in the deck using the Fisher-Yates algorithm
-// The shuffle is seeded with the current time to ensure different results each time
+// The shuffle draws from the source set with SetSource or Seed, or is seeded with the
+// current time to ensure different results each time
 func (d *Deck) Shuffle() {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	r := d.rng
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
 	r.Shuffle(len(d.Cards), func(i, j int) {
 		d.Cards[i], d.Cards[j] = d.Cards[j], d.Cards[i]
 	})
@@ -88,17 +109,17 @@ func (d *Deck) Shuffle() {
 
 // Times creates a new deck with multiple copies of the current deck
 // count: number of copies to create (must be positive)
-// Returns a new Deck containing count copies of the current deck's cards
+// Returns a new Deck containing count copies of the current deck's cards, sharing its source of randomness
 func (d *Deck) Times(count int) *Deck {
 	if count <= 0 {
-		return &Deck{Cards: []*Card{}}
+		return &Deck{Cards: []*Card{}, rng: d.rng}
 	}
 
 	var cards []*Card
 	for i := 0; i < count; i++ {
 		cards = append(cards, d.Cards...)
 	}
-	return &Deck{Cards: cards}
+	return &Deck{Cards: cards, rng: d.rng}
 }
 
 // ComboCount calculates the number of possible combinations when drawing a specified number of cards
@@ -118,6 +139,42 @@ func (d *Deck) ComboCount(drawCount int) int {
 	return result
 }
 
+// ForEachCombination calls visit once for every combination of drawCount cards in the deck,
+// in lexicographic order of card positions.
+// The slice passed to visit is reused between calls and must be copied to be retained.
+func (d *Deck) ForEachCombination(drawCount int, visit func(cards []*Card)) {
+	n := len(d.Cards)
+	if drawCount <= 0 || drawCount > n {
+		return
+	}
+
+	indices := make([]int, drawCount)
+	for i := range indices {
+		indices[i] = i
+	}
+	cards := make([]*Card, drawCount)
+
+	for {
+		for i, index := range indices {
+			cards[i] = d.Cards[index]
+		}
+		visit(cards)
+
+		// Advance the rightmost index that still has room to move
+		i := drawCount - 1
+		for i >= 0 && indices[i] == n-drawCount+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		indices[i]++
+		for j := i + 1; j < drawCount; j++ {
+			indices[j] = indices[j-1] + 1
+		}
+	}
+}
+
 // DrawWithLimitHands generates randomized hands of cards
 // drawCount: number of cards per hand (must be positive and not exceed deck size)
 // limit: maximum number of hands to generate (must be positive)
@@ -385,3 +385,66 @@ func (s *DeckTestSuite) TestDrawWithLimitHands() {
 		})
 	}
 }
+
+func (s *DeckTestSuite) TestForEachCombination() {
+	tests := []struct {
+		name      string
+		deck      *Deck
+		drawCount int
+	}{
+		{name: "Standard deck draw 2", deck: NewDeck(), drawCount: 2},
+		{name: "Standard deck draw 1", deck: NewDeck(), drawCount: 1},
+		{name: "Small deck draw all", deck: NewDeck("A♠", "K♠"), drawCount: 50},
+		{name: "Draw more than deck size", deck: NewDeck(), drawCount: 53},
+		{name: "Draw zero cards", deck: NewDeck(), drawCount: 0},
+		{name: "Empty deck", deck: &Deck{Cards: []*Card{}}, drawCount: 2},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			seen := make(map[string]bool)
+			count := 0
+			tt.deck.ForEachCombination(tt.drawCount, func(cards []*Card) {
+				assert.Len(s.T(), cards, tt.drawCount)
+				key := NewHand(append([]*Card{}, cards...)...).String()
+				assert.False(s.T(), seen[key], "combination %s visited twice", key)
+				seen[key] = true
+				count++
+			})
+			assert.Equal(s.T(), tt.deck.ComboCount(tt.drawCount), count)
+		})
+	}
+}
+
+func (s *DeckTestSuite) TestSeededShuffle() {
+	order := func(d *Deck) string {
+		cards := make([]string, len(d.Cards))
+		for i, card := range d.Cards {
+			cards[i] = card.String()
+		}
+		return strings.Join(cards, " ")
+	}
+	shuffled := func(seed int64) string {
+		d := NewDeck()
+		d.Seed(seed)
+		d.Shuffle()
+		return order(d)
+	}
+
+	assert.Equal(s.T(), shuffled(1), shuffled(1), "The same seed should shuffle the same order")
+	assert.NotEqual(s.T(), shuffled(1), shuffled(2), "Different seeds should shuffle different orders")
+
+	// Successive shuffles continue the source and copies share it
+	d := NewDeck()
+	d.Seed(1)
+	d.Shuffle()
+	first := order(d)
+	d.Shuffle()
+	assert.NotEqual(s.T(), first, order(d))
+	assert.Len(s.T(), d.Times(2).DrawWithLimitHands(2, 10), 10)
+
+	// A nil source falls back to seeding with the current time
+	d.SetSource(nil)
+	d.Shuffle()
+	assert.Equal(s.T(), 52, d.Count())
+}
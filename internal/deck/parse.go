@@ -0,0 +1,82 @@
+package deck
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// asciiSuits maps the ASCII suit letters to the suit symbols used by Card
+var asciiSuits = map[string]string{"s": "♠", "h": "♥", "d": "♦", "c": "♣"}
+
+// ParseCard parses a card written in ASCII or unicode notation:
+//   - values 2-10, J, Q, K, A, with "T" accepted for 10, in either case
+//   - suits s, h, d, c in either case, or ♠, ♥, ♦, ♣
+//   - jokers as "JokerRed" and "JokerBW", as Card.String writes them, or "RJ" and "BJ"
+//
+// For example "As", "Td", "10h" and "A♠" are all valid.
+// Returns an error describing what is wrong with the card otherwise.
+func ParseCard(s string) (*Card, error) {
+	switch strings.ToUpper(s) {
+	case "JOKERRED", "RJ":
+		return NewCard("Joker", "Red"), nil
+	case "JOKERBW", "BJ":
+		return NewCard("Joker", "BW"), nil
+	}
+
+	suitRune, size := utf8.DecodeLastRuneInString(s)
+	if len(s) <= size {
+		return nil, fmt.Errorf("invalid card %q: expected a value followed by a suit, such as \"As\" or \"10♥\"", s)
+	}
+
+	suit := string(suitRune)
+	if symbol, ok := asciiSuits[strings.ToLower(suit)]; ok {
+		suit = symbol
+	}
+	switch suit {
+	case "♠", "♥", "♦", "♣":
+	default:
+		return nil, fmt.Errorf("invalid card %q: unknown suit %q, expected one of s, h, d, c, ♠, ♥, ♦, ♣", s, string(suitRune))
+	}
+
+	value := strings.ToUpper(s[:len(s)-size])
+	if value == "T" {
+		value = "10"
+	}
+	switch value {
+	case "2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A":
+	default:
+		return nil, fmt.Errorf("invalid card %q: unknown value %q, expected one of 2-10, T, J, Q, K, A", s, s[:len(s)-size])
+	}
+	return NewCard(value, suit), nil
+}
+
+// ParseCards parses cards separated by spaces or commas, such as "As Kh" or "A♠, 10♥"
+// Returns an error for the first card that cannot be parsed.
+func ParseCards(s string) ([]*Card, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+	cards := make([]*Card, len(fields))
+	for i, field := range fields {
+		card, err := ParseCard(field)
+		if err != nil {
+			return nil, err
+		}
+		cards[i] = card
+	}
+	return cards, nil
+}
+
+// CheckDuplicates returns an error naming the first card that appears more than once
+// among the cards, as when the same card is given to two players of a single-deck game.
+func CheckDuplicates(cards []*Card) error {
+	seen := make(map[string]bool, len(cards))
+	for _, card := range cards {
+		if seen[card.String()] {
+			return fmt.Errorf("duplicate card %s", card)
+		}
+		seen[card.String()] = true
+	}
+	return nil
+}
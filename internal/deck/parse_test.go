@@ -0,0 +1,93 @@
+package deck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ParseTestSuite struct {
+	suite.Suite
+}
+
+func TestParseSuite(t *testing.T) {
+	suite.Run(t, new(ParseTestSuite))
+}
+
+func (s *ParseTestSuite) TestParseCard() {
+	tests := []struct {
+		input    string
+		expected *Card
+	}{
+		{input: "As", expected: NewCard("A", "♠")},
+		{input: "kh", expected: NewCard("K", "♥")},
+		{input: "Td", expected: NewCard("10", "♦")},
+		{input: "10C", expected: NewCard("10", "♣")},
+		{input: "2♠", expected: NewCard("2", "♠")},
+		{input: "10♥", expected: NewCard("10", "♥")},
+		{input: "q♦", expected: NewCard("Q", "♦")},
+		{input: "JokerRed", expected: NewCard("Joker", "Red")},
+		{input: "JokerBW", expected: NewCard("Joker", "BW")},
+		{input: "rj", expected: NewCard("Joker", "Red")},
+		{input: "BJ", expected: NewCard("Joker", "BW")},
+	}
+	for _, tt := range tests {
+		s.Run(tt.input, func() {
+			card, err := ParseCard(tt.input)
+			assert.NoError(s.T(), err)
+			assert.Equal(s.T(), tt.expected, card)
+		})
+	}
+
+	// Every card of a deck parses back from its string
+	for _, card := range NewDeckWithJokers().Cards {
+		parsed, err := ParseCard(card.String())
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), card, parsed)
+	}
+}
+
+func (s *ParseTestSuite) TestParseCardErrors() {
+	tests := []struct {
+		input   string
+		message string
+	}{
+		{input: "", message: "expected a value followed by a suit"},
+		{input: "s", message: "expected a value followed by a suit"},
+		{input: "♠", message: "expected a value followed by a suit"},
+		{input: "Zx", message: "unknown suit \"x\""},
+		{input: "Zs", message: "unknown value \"Z\""},
+		{input: "1s", message: "unknown value \"1\""},
+		{input: "11♥", message: "unknown value \"11\""},
+		{input: "10", message: "unknown suit \"0\""},
+		{input: "A♤", message: "unknown suit \"♤\""},
+	}
+	for _, tt := range tests {
+		s.Run(tt.input, func() {
+			_, err := ParseCard(tt.input)
+			assert.ErrorContains(s.T(), err, tt.message)
+		})
+	}
+}
+
+func (s *ParseTestSuite) TestParseCards() {
+	cards, err := ParseCards(" As Kh,Td, 10♣ ")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []*Card{NewCard("A", "♠"), NewCard("K", "♥"), NewCard("10", "♦"), NewCard("10", "♣")}, cards)
+
+	cards, err = ParseCards("")
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), cards)
+
+	_, err = ParseCards("As Xx")
+	assert.ErrorContains(s.T(), err, "Xx")
+}
+
+func (s *ParseTestSuite) TestCheckDuplicates() {
+	cards, _ := ParseCards("As Kh Qd")
+	assert.NoError(s.T(), CheckDuplicates(cards))
+
+	cards, _ = ParseCards("As Kh A♠")
+	assert.EqualError(s.T(), CheckDuplicates(cards), "duplicate card A♠")
+}
@@ -9,9 +9,19 @@ import (
 // Combiner handles card combination logic
 type Combiner struct {
 	currentLevel string
-	valueToRank  map[string]int
+	valueToRank  map[string]int // Natural ranks of the values 2 to A
 }
 
+// cardValues are the card values other than jokers, in natural order
+var cardValues = []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
+
+// Ranks above A in Guandan order: the level card, then the small and big jokers
+const (
+	levelRank      = 15
+	smallJokerRank = 16
+	bigJokerRank   = 17
+)
+
 // CombinationType represents the type of card combination
 type CombinationType int
 
@@ -29,10 +39,37 @@ const (
 	JokerBomb
 )
 
+// String returns the name of the combination type
+func (t CombinationType) String() string {
+	return [...]string{
+		"Invalid Combination",
+		"Single",
+		"Pair",
+		"Triple",
+		"Plate",
+		"Tube",
+		"Full House",
+		"Straight",
+		"Bomb",
+		"Straight Flush",
+		"Joker Bomb",
+	}[t]
+}
+
 // CombinationStrength contains detailed information about a combination
 type CombinationStrength struct {
-	Type   CombinationType
-	Values []int // Card values in descending order of importance
+	Type CombinationType
+	// Card ranks in descending order. Straights, tubes and plates use the natural
+	// ranks 2 to A; other combinations rank the level card above A and the jokers above it.
+	Values []int
+	// Substitutions are the cards the wild cards stand in for
+	Substitutions []Substitution
+}
+
+// Substitution is a wild card played as another card
+type Substitution struct {
+	Wild *deck.Card
+	As   *deck.Card
 }
 
 // NewCombiner creates a new Combiner instance
@@ -43,24 +80,96 @@ func NewCombiner(currentLevel string) *Combiner {
 			"2": 2, "3": 3, "4": 4, "5": 5,
 			"6": 6, "7": 7, "8": 8, "9": 9,
 			"10": 10, "J": 11, "Q": 12,
-			"K": 13, "A": 14, "Joker": 15,
+			"K": 13, "A": 14,
 		},
 	}
 }
 
-// EvaluateCombination determines the type and strength of a card combination
+// EvaluateCombination determines the type and strength of a card combination.
+// The heart level cards are wild ("逢人配") and stand in for any card but a joker: cards
+// with wild cards are classified as the strongest combination any substitution forms,
+// which is reported in Substitutions. A wild card playing as itself is not substituted.
 func (c *Combiner) EvaluateCombination(cards []*deck.Card) CombinationStrength {
+	var wilds []int
+	suits := make(map[string]bool)
+	for i, card := range cards {
+		if c.IsWild(card) {
+			wilds = append(wilds, i)
+		} else if card.Value != "Joker" {
+			suits[card.Suit] = true
+		}
+	}
+	best := c.evaluate(cards)
+	if len(wilds) == 0 {
+		return best
+	}
+
+	// Suits only matter to straight flushes, so the wild cards take the suits of the
+	// other cards, or any suit when there are none
+	var substituteSuits []string
+	for _, suit := range []string{"♠", "♥", "♦", "♣"} {
+		if suits[suit] {
+			substituteSuits = append(substituteSuits, suit)
+		}
+	}
+	if len(substituteSuits) == 0 {
+		substituteSuits = []string{"♠"}
+	}
+
+	substituted := append([]*deck.Card{}, cards...)
+	var substitute func(n int)
+	substitute = func(n int) {
+		if n == len(wilds) {
+			candidate := c.evaluate(substituted)
+			if c.stronger(candidate, best) {
+				for _, i := range wilds {
+					candidate.Substitutions = append(candidate.Substitutions, Substitution{Wild: cards[i], As: substituted[i]})
+				}
+				best = candidate
+			}
+			return
+		}
+		for _, value := range cardValues {
+			for _, suit := range substituteSuits {
+				substituted[wilds[n]] = deck.NewCard(value, suit)
+				substitute(n + 1)
+			}
+		}
+	}
+	substitute(0)
+	return best
+}
+
+// IsWild reports whether the card is wild: a heart of the level
+func (c *Combiner) IsWild(card *deck.Card) bool {
+	return card.Value == c.currentLevel && card.Suit == "♥"
+}
+
+// stronger reports whether combination a is stronger than b, with invalid combinations
+// the weakest: bombs by their power, then by type, number of cards and rank.
+func (c *Combiner) stronger(a, b CombinationStrength) bool {
+	if a.Type == InvalidCombination || b.Type == InvalidCombination {
+		return b.Type == InvalidCombination && a.Type != InvalidCombination
+	}
+	if powerA, powerB := bombPower(a), bombPower(b); powerA != powerB {
+		return powerA > powerB
+	}
+	if a.Type != b.Type {
+		return a.Type > b.Type
+	}
+	if len(a.Values) != len(b.Values) {
+		return len(a.Values) > len(b.Values)
+	}
+	return c.mainRank(a) > c.mainRank(b)
+}
+
+// evaluate determines the type and strength of a combination with every card playing as itself
+func (c *Combiner) evaluate(cards []*deck.Card) CombinationStrength {
 	strength := CombinationStrength{
 		Type:   InvalidCombination,
 		Values: make([]int, 0, len(cards)),
 	}
 
-	// Convert card values to ranks
-	for _, card := range cards {
-		strength.Values = append(strength.Values, c.valueToRank[card.Value])
-	}
-	sort.Sort(sort.Reverse(sort.IntSlice(strength.Values)))
-
 	// Check combination types in descending order of strength
 	switch {
 	case c.isJokerBomb(cards):
@@ -85,77 +194,135 @@ func (c *Combiner) EvaluateCombination(cards []*deck.Card) CombinationStrength {
 		strength.Type = Single
 	}
 
+	// Convert cards to ranks, in natural order for sequences with an Ace below the 2 as 1
+	lowAce := false
+	switch strength.Type {
+	case Straight, StraightFlush:
+		top, _ := c.sequenceTop(cards, 1, 5)
+		lowAce = top != 14
+	case Tube:
+		top, _ := c.sequenceTop(cards, 2, 3)
+		lowAce = top != 14
+	case Plate:
+		top, _ := c.sequenceTop(cards, 3, 2)
+		lowAce = top != 14
+	}
+	for _, card := range cards {
+		switch strength.Type {
+		case Straight, StraightFlush, Tube, Plate:
+			rank := c.valueToRank[card.Value]
+			if rank == 14 && lowAce {
+				rank = 1
+			}
+			strength.Values = append(strength.Values, rank)
+		default:
+			strength.Values = append(strength.Values, c.Rank(card))
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(strength.Values)))
+
 	return strength
 }
 
-// isSingle checks if cards form a single card combination
-func (c *Combiner) isSingle(cards []*deck.Card) bool {
-	return len(cards) == 1
+// Rank returns the rank of a card in Guandan order at the combiner's level:
+// 2 to A in natural order except the level card, which ranks above A,
+// then the small joker and the big (red) joker.
+func (c *Combiner) Rank(card *deck.Card) int {
+	switch {
+	case card.Value == "Joker" && card.Suit == "Red":
+		return bigJokerRank
+	case card.Value == "Joker":
+		return smallJokerRank
+	case card.Value == c.currentLevel:
+		return levelRank
+	}
+	return c.valueToRank[card.Value]
 }
 
-// isPair checks if cards form a pair combination
-func (c *Combiner) isPair(cards []*deck.Card) bool {
-	if len(cards) != 2 {
+// Beats reports whether the next combination beats the previous one in Guandan order:
+//   - the joker bomb beats everything
+//   - bombs beat every other combination; a bomb with more cards beats one with fewer,
+//     and a straight flush ranks between the 5-card and 6-card bombs
+//   - otherwise both must be of the same type and number of cards, and next must rank higher
+//
+// Combinations are ranked by their deciding card, such as the triple of a full house or the
+// top of a straight, as given by their Values.
+func (c *Combiner) Beats(prev, next CombinationStrength) bool {
+	if prev.Type == InvalidCombination || next.Type == InvalidCombination {
 		return false
 	}
-	return cards[0].Value == cards[1].Value
-}
 
-// isTriple checks if cards form a triple combination
-func (c *Combiner) isTriple(cards []*deck.Card) bool {
-	if len(cards) != 3 {
+	prevPower, nextPower := bombPower(prev), bombPower(next)
+	if prevPower != nextPower {
+		return nextPower > prevPower
+	}
+	if prev.Type != next.Type || len(prev.Values) != len(next.Values) {
 		return false
 	}
-	return cards[0].Value == cards[1].Value &&
-		cards[1].Value == cards[2].Value
+	return c.mainRank(next) > c.mainRank(prev)
 }
 
-// isPlate checks if cards form a plate combination (2 consecutive triples)
-func (c *Combiner) isPlate(cards []*deck.Card) bool {
-	if len(cards) != 6 {
-		return false
+// bombPower orders bombs: 4 and 5-card bombs, the straight flush, bombs of 6 or more
+// cards, then the joker bomb. Other combinations have no power.
+func bombPower(s CombinationStrength) int {
+	switch s.Type {
+	case JokerBomb:
+		return 100
+	case StraightFlush:
+		return 6
+	case Bomb:
+		if len(s.Values) >= 6 {
+			return len(s.Values) + 1
+		}
+		return len(s.Values)
 	}
+	return 0
+}
 
-	// Split into two triples
-	first := cards[:3]
-	second := cards[3:]
+// mainRank returns the rank that decides a combination: the top card of a sequence,
+// otherwise the most common rank and the highest among equally common ones.
+func (c *Combiner) mainRank(s CombinationStrength) int {
+	switch s.Type {
+	case Straight, StraightFlush, Tube, Plate:
+		return s.Values[0]
+	}
 
-	if !c.isTriple(first) || !c.isTriple(second) {
-		return false
+	count := make(map[int]int)
+	best := 0
+	for _, v := range s.Values {
+		count[v]++
+		if best == 0 || count[v] > count[best] || (count[v] == count[best] && v > best) {
+			best = v
+		}
 	}
+	return best
+}
 
-	// Check if consecutive
-	values := []string{first[0].Value, second[0].Value}
-	sort.Strings(values)
-	return c.areConsecutive(values[0], values[1])
+// isSingle checks if cards form a single card combination
+func (c *Combiner) isSingle(cards []*deck.Card) bool {
+	return len(cards) == 1
 }
 
-// isTube checks if cards form a tube combination (3 consecutive pairs)
-func (c *Combiner) isTube(cards []*deck.Card) bool {
-	if len(cards) != 6 {
-		return false
-	}
+// isPair checks if cards form a pair combination
+func (c *Combiner) isPair(cards []*deck.Card) bool {
+	return len(cards) == 2 && c.sameRank(cards)
+}
 
-	// Split into three pairs
-	pairs := [][]*deck.Card{
-		cards[:2],
-		cards[2:4],
-		cards[4:],
-	}
+// isTriple checks if cards form a triple combination
+func (c *Combiner) isTriple(cards []*deck.Card) bool {
+	return len(cards) == 3 && c.sameRank(cards)
+}
 
-	// Check each pair and collect values
-	values := make([]string, 0, 3)
-	for _, pair := range pairs {
-		if !c.isPair(pair) {
-			return false
-		}
-		values = append(values, pair[0].Value)
-	}
+// isPlate checks if cards form a plate combination (2 consecutive triples)
+func (c *Combiner) isPlate(cards []*deck.Card) bool {
+	_, ok := c.sequenceTop(cards, 3, 2)
+	return ok
+}
 
-	// Check if consecutive
-	sort.Strings(values)
-	return c.areConsecutive(values[0], values[1]) &&
-		c.areConsecutive(values[1], values[2])
+// isTube checks if cards form a tube combination (3 consecutive pairs)
+func (c *Combiner) isTube(cards []*deck.Card) bool {
+	_, ok := c.sequenceTop(cards, 2, 3)
+	return ok
 }
 
 // isFullHouse checks if cards form a full house combination
@@ -164,16 +331,16 @@ func (c *Combiner) isFullHouse(cards []*deck.Card) bool {
 		return false
 	}
 
-	// Count card values
-	valueCount := make(map[string]int)
+	// Count card ranks
+	rankCount := make(map[int]int)
 	for _, card := range cards {
-		valueCount[card.Value]++
+		rankCount[c.Rank(card)]++
 	}
 
 	// Should have one triple and one pair
 	hasTriple := false
 	hasPair := false
-	for _, count := range valueCount {
+	for _, count := range rankCount {
 		if count == 3 {
 			hasTriple = true
 		} else if count == 2 {
@@ -186,60 +353,17 @@ func (c *Combiner) isFullHouse(cards []*deck.Card) bool {
 
 // isStraight checks if cards form a straight combination
 func (c *Combiner) isStraight(cards []*deck.Card) bool {
-	if len(cards) != 5 {
-		return false
-	}
-
-	// Get unique values
-	uniqueValues := make([]string, 0, 5)
-	seen := make(map[string]bool)
-	for _, card := range cards {
-		if !seen[card.Value] {
-			seen[card.Value] = true
-			uniqueValues = append(uniqueValues, card.Value)
-		}
-	}
-
-	if len(uniqueValues) != 5 {
-		return false
-	}
-
-	// Sort values
-	sort.Strings(uniqueValues)
-
-	// Check if consecutive
-	for i := 0; i < 4; i++ {
-		if !c.areConsecutive(uniqueValues[i], uniqueValues[i+1]) {
-			return false
-		}
-	}
-
-	return true
+	_, ok := c.sequenceTop(cards, 1, 5)
+	return ok
 }
 
 // isBomb checks if cards form a bomb combination
 func (c *Combiner) isBomb(cards []*deck.Card) bool {
-	if len(cards) < 4 {
-		return false
-	}
-
-	// All cards must have same value
-	firstValue := cards[0].Value
-	for _, card := range cards {
-		if card.Value != firstValue {
-			return false
-		}
-	}
-
-	return true
+	return len(cards) >= 4 && c.sameRank(cards)
 }
 
 // isStraightFlush checks if cards form a straight flush combination
 func (c *Combiner) isStraightFlush(cards []*deck.Card) bool {
-	if len(cards) != 5 {
-		return false
-	}
-
 	// First check if straight
 	if !c.isStraight(cards) {
 		return false
@@ -271,42 +395,60 @@ func (c *Combiner) isJokerBomb(cards []*deck.Card) bool {
 	return true
 }
 
-// areConsecutive checks if two card values are consecutive
-// considering the current level
-func (c *Combiner) areConsecutive(value1, value2 string) bool {
-	order := []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
-
-	// Find indices
-	index1 := -1
-	index2 := -1
-	for i, val := range order {
-		if val == value1 {
-			index1 = i
-		}
-		if val == value2 {
-			index2 = i
+// sameRank checks if all cards have the same rank; a small and a big joker do not
+func (c *Combiner) sameRank(cards []*deck.Card) bool {
+	for _, card := range cards {
+		if c.Rank(card) != c.Rank(cards[0]) {
+			return false
 		}
 	}
+	return len(cards) > 0
+}
 
-	if index1 == -1 || index2 == -1 {
-		return false
+// sequenceTop checks if cards form length consecutive groups of width cards of the same
+// natural rank, such as 5 singles for a straight or 3 pairs for a tube, and returns the
+// rank of the top group. The Ace plays high after the K or low before the 2, when the
+// top is returned as the highest rank with the Ace as 1; sequences do not wrap around.
+// The level card keeps its natural place and jokers are never part of a sequence.
+func (c *Combiner) sequenceTop(cards []*deck.Card, width, length int) (int, bool) {
+	if len(cards) != width*length {
+		return 0, false
 	}
 
-	// Handle wrap-around for current level
-	if value1 == c.currentLevel && value2 == "2" {
-		return true
+	counts := make(map[int]int, length)
+	for _, card := range cards {
+		rank, ok := c.valueToRank[card.Value]
+		if !ok {
+			return 0, false
+		}
+		counts[rank]++
 	}
-	if value2 == c.currentLevel && value1 == "2" {
-		return true
+	if len(counts) != length {
+		return 0, false
 	}
 
-	return abs(index1-index2) == 1
-}
+	low, high := 15, 0
+	for rank, count := range counts {
+		if count != width {
+			return 0, false
+		}
+		low, high = min(low, rank), max(high, rank)
+	}
+	if high-low == length-1 {
+		return high, true
+	}
 
-// abs returns absolute value of an integer
-func abs(x int) int {
-	if x < 0 {
-		return -x
+	// An Ace below the 2: the other ranks run up from 2
+	if counts[14] > 0 && counts[2] > 0 {
+		top := 0
+		for rank := range counts {
+			if rank != 14 {
+				top = max(top, rank)
+			}
+		}
+		if top == length {
+			return top, true
+		}
 	}
-	return x
+	return 0, false
 }
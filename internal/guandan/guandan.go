@@ -1,6 +1,11 @@
 package guandan
 
 import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
 	"github.com/genewoo/joker/internal/deck"
 )
 
@@ -12,6 +17,19 @@ type Game struct {
 	dealer       int
 	deck         *deck.Deck
 	lastRanking  [4]int
+	source       rand.Source    // Source of randomness for shuffling; nil seeds from the current time
+	returns      ReturnStrategy // How winners choose the cards they return for tributes
+	tributes     []Tribute      // Tributes paid before the deal
+	strategies   [4]Strategy    // Strategies playing the seats, nil for seats played through Play and Pass
+
+	// State of the deal being played
+	combiner *Combiner
+	turn     int    // Seat to play, 0 when no deal is being played
+	trick    *Play  // Play to beat in the current trick, nil when a new trick is led
+	passes   int    // Passes since the trick's last play
+	finished []int  // Seats in the order they went out
+	plays    []Play // Plays and passes of the deal
+	ranking  [4]int // Finishing order once the deal is over
 }
 
 // Player represents a game player
@@ -101,21 +119,77 @@ func NewGame(lastRanking [4]int, teamLevels [2]string) *Game {
 	}
 }
 
+// ParseLevel parses a level from 2 to A, accepting "T" for 10 and lower case letters
+func ParseLevel(s string) (string, error) {
+	level := strings.ToUpper(strings.TrimSpace(s))
+	if level == "T" {
+		level = "10"
+	}
+	for _, l := range levels {
+		if l == level {
+			return level, nil
+		}
+	}
+	return "", fmt.Errorf("invalid level %q: must be one of 2-10, J, Q, K, A", s)
+}
+
+// ParseRanking parses the finishing order of a deal as four seats from 1 to 4 separated by
+// commas, dashes or spaces, such as "1,3,2,4" or "1-3-2-4"
+func ParseRanking(s string) ([4]int, error) {
+	var ranking [4]int
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == '-' || r == ' '
+	})
+	if len(fields) != 4 {
+		return ranking, fmt.Errorf("invalid ranking %q: must list the 4 seats in finishing order, such as 1,3,2,4", s)
+	}
+	seen := make(map[int]bool)
+	for i, field := range fields {
+		seat, err := strconv.Atoi(field)
+		if err != nil || seat < 1 || seat > 4 || seen[seat] {
+			return ranking, fmt.Errorf("invalid ranking %q: must list each seat from 1 to 4 once", s)
+		}
+		seen[seat] = true
+		ranking[i] = seat
+	}
+	return ranking, nil
+}
+
+// Level returns the level the deal is played at
+func (g *Game) Level() string {
+	return g.currentLevel
+}
+
+// SetSource sets the source of randomness used to shuffle the decks, so that a
+// source in the same state deals the same hands. A nil src restores seeding each
+// shuffle with the current time.
+func (g *Game) SetSource(src rand.Source) {
+	g.source = src
+}
+
+// Seed makes the hands dealt reproducible from the given seed
+func (g *Game) Seed(seed int64) {
+	g.SetSource(rand.NewSource(seed))
+}
+
 // DealCards deals cards to players based on last game's ranking
 func (g *Game) DealCards() {
 	// Initialize deck
 	d := deck.NewDeckWithJokers()
 	d = d.Times(2)
+	d.SetSource(g.source)
 	d.Shuffle()
 	g.deck = d
 
-	// Set dealer as last game's first player
+	// Set dealer as last game's first player, until tributes are paid
 	g.dealer = g.lastRanking[0]
+	g.tributes = nil
 
 	// Deal cards in reverse order of last game's ranking
 	for i := len(g.lastRanking) - 1; i >= 0; i-- {
 		player := g.players[g.lastRanking[i]-1]
 		player.hand = deck.NewHand()
+		player.hand.SetOrganizer(NewLevelOrganizer(g.currentLevel))
 
 		// Deal 27 cards to each player
 		for j := 0; j < 27; j++ {
@@ -126,90 +200,6 @@ func (g *Game) DealCards() {
 	}
 }
 
-// SwapCards implements the special card swapping rules
-// Returns false if both givers have red joker (no swap occurs), true otherwise (swap proceeds)
-func (g *Game) SwapCards() bool {
-	lastTwoSameTeam := g.players[g.lastRanking[2]-1].team == g.players[g.lastRanking[3]-1].team
-
-	// Convert single player swap to slice format
-	givers := []*Player{g.players[g.lastRanking[3]-1]}
-	receivers := []*Player{g.players[g.lastRanking[0]-1]}
-
-	if lastTwoSameTeam {
-		// Last two players are from same team - both will give cards
-		givers = []*Player{
-			g.players[g.lastRanking[2]-1],
-			g.players[g.lastRanking[3]-1],
-		}
-		receivers = []*Player{
-			g.players[g.lastRanking[0]-1],
-			g.players[g.lastRanking[1]-1],
-		}
-	}
-
-	// Special rule: Check red joker conditions to prevent swap
-	shouldPreventSwap := false
-
-	// Count total red jokers across all givers
-	totalRedJokers := 0
-	for _, giver := range givers {
-		for _, card := range giver.hand.Cards {
-			if card.Value == "Joker" && card.Suit == "Red" {
-				totalRedJokers++
-			}
-		}
-	}
-
-	// Prevent swap if total red jokers == 2 (applies to both single and multiple givers)
-	shouldPreventSwap = (totalRedJokers == 2)
-
-	// If conditions met, return false to prevent swap
-	if shouldPreventSwap {
-		return false
-	}
-	// Proceed with normal swap if rule conditions not met
-	g.swapCards(givers, receivers)
-	return true
-}
-
-// swapCards handles card swapping between givers and receivers
-func (g *Game) swapCards(givers, receivers []*Player) {
-	for i := range givers {
-		card := g.findSwapCard(givers[i])
-		if card != nil {
-			receiver := receivers[i]
-			returnCard := g.findReturnCard(receiver)
-
-			if returnCard != nil {
-				givers[i].hand.RemoveCard(givers[i].hand.IndexOf(card))
-				receiver.hand.AddCard(card)
-
-				receiver.hand.RemoveCard(receiver.hand.IndexOf(returnCard))
-				givers[i].hand.AddCard(returnCard)
-			}
-		}
-	}
-}
-
-// findSwapCard finds the biggest card to swap that's not a level card or heart
-func (g *Game) findSwapCard(player *Player) *deck.Card {
-	player.hand.Sort()
-
-	for _, card := range player.hand.Cards {
-		if card.Value != g.currentLevel && card.Suit != "♥" {
-			return card
-		}
-	}
-	return player.hand.Cards[0]
-}
-
-// findReturnCard finds a card to return that's not bigger than 10
-func (g *Game) findReturnCard(player *Player) *deck.Card {
-	player.hand.Sort()
-	// return the lowest card to give away (not properly implemented yet)
-	return player.hand.Cards[player.hand.Count()-1]
-}
-
 // UpdateLevel updates the game level based on winning team
 func (g *Game) UpdateLevel(winningTeam *Team) {
 	g.currentLevel = winningTeam.level
@@ -5,9 +5,34 @@ import (
 
 	"github.com/genewoo/joker/internal/deck"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
+// dealtHands parses the hands of seats 1-4, one notation per seat
+func dealtHands(t *testing.T, notations ...string) [4]*deck.Hand {
+	var dealt [4]*deck.Hand
+	for i, notation := range notations {
+		cards, err := deck.ParseCards(notation)
+		require.NoError(t, err)
+		dealt[i] = deck.NewHand(cards...)
+	}
+	return dealt
+}
+
+// newDealtGame creates a game at the level with the hands of seats 1-4, after a deal
+// that finished in seat order
+func newDealtGame(t *testing.T, level string, notations ...string) *Game {
+	return newGameWithHands([4]int{1, 2, 3, 4}, [2]string{level, level}, dealtHands(t, notations...))
+}
+
+// playCards plays the cards of the notation from the seat's hand
+func playCards(t *testing.T, game *Game, seat int, notation string) {
+	cards, err := deck.ParseCards(notation)
+	require.NoError(t, err)
+	require.NoError(t, game.Play(seat, cards), "seat %d plays %s", seat, notation)
+}
+
 type GuandanTestSuite struct {
 	suite.Suite
 	lastRanking [4]int
@@ -223,13 +248,22 @@ func (suite *GuandanTestSuite) TestSwapCards() {
 	})
 
 	suite.Run("Team swap", func() {
+		hands := [4]*deck.Hand{
+			deck.NewHand(&deck.Card{Value: "10", Suit: "♠"}),
+			deck.NewHand(&deck.Card{Value: "K", Suit: "♠"}),
+			deck.NewHand(&deck.Card{Value: "9", Suit: "♠"}),
+			deck.NewHand(&deck.Card{Value: "J", Suit: "♠"}),
+		}
 		game := newGameWithHands([4]int{1, 3, 2, 4}, suite.teamLevels, hands)
 		game.SwapCards()
 
-		// assert.Equal(suite.T(), "K", game.players[0].hand.Cards[0].Value)
-		// assert.Equal(suite.T(), "10", game.players[1].hand.Cards[0].Value)
-		// assert.Equal(suite.T(), "J", game.players[2].hand.Cards[0].Value)
-		// assert.Equal(suite.T(), "9", game.players[3].hand.Cards[0].Value)
+		// Seats 2 and 4 hold the 9 and the J. The higher tribute goes to the first player,
+		// who returns their 10; the third player has only the K to return.
+		assert.Equal(suite.T(), "J", game.players[0].hand.Cards[0].Value)
+		assert.Equal(suite.T(), "K", game.players[1].hand.Cards[0].Value)
+		assert.Equal(suite.T(), "9", game.players[2].hand.Cards[0].Value)
+		assert.Equal(suite.T(), "10", game.players[3].hand.Cards[0].Value)
+		assert.Equal(suite.T(), 4, game.Dealer())
 	})
 }
 
@@ -262,3 +296,59 @@ func (suite *GuandanTestSuite) TestNextLevel() {
 		assert.Equal(suite.T(), "A", winningTeam.level)
 	})
 }
+
+func (suite *GuandanTestSuite) TestSeededDeal() {
+	deal := func(seed int64) [4]string {
+		game := NewGame(suite.lastRanking, suite.teamLevels)
+		game.Seed(seed)
+		game.DealCards()
+		var hands [4]string
+		for i, player := range game.players {
+			hands[i] = player.hand.String()
+		}
+		return hands
+	}
+
+	assert.Equal(suite.T(), deal(42), deal(42), "The same seed should deal the same hands")
+	assert.NotEqual(suite.T(), deal(42), deal(43), "Different seeds should deal different hands")
+}
+
+func (suite *GuandanTestSuite) TestParseRanking() {
+	tests := []struct {
+		input    string
+		expected [4]int
+		wantErr  bool
+	}{
+		{input: "1,3,2,4", expected: [4]int{1, 3, 2, 4}},
+		{input: "4-2-1-3", expected: [4]int{4, 2, 1, 3}},
+		{input: "2 1 4 3", expected: [4]int{2, 1, 4, 3}},
+		{input: "1,2,3", wantErr: true},
+		{input: "1,2,3,3", wantErr: true},
+		{input: "1,2,3,5", wantErr: true},
+		{input: "a,b,c,d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.input, func() {
+			ranking, err := ParseRanking(tt.input)
+			if tt.wantErr {
+				assert.Error(suite.T(), err)
+				return
+			}
+			assert.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tt.expected, ranking)
+		})
+	}
+}
+
+func (suite *GuandanTestSuite) TestParseLevel() {
+	for input, expected := range map[string]string{"2": "2", "10": "10", "t": "10", "j": "J", "A": "A"} {
+		level, err := ParseLevel(input)
+		assert.NoError(suite.T(), err, input)
+		assert.Equal(suite.T(), expected, level)
+	}
+	for _, input := range []string{"1", "11", "Joker", ""} {
+		_, err := ParseLevel(input)
+		assert.Error(suite.T(), err, input)
+	}
+}
@@ -0,0 +1,260 @@
+package guandan
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/genewoo/joker/internal/deck"
+)
+
+// LegalPlay is a combination that can be played from a hand
+type LegalPlay struct {
+	Cards       []*deck.Card
+	Combination CombinationStrength
+}
+
+// sequenceValues are the card values in sequence order, with the Ace both low and high
+var sequenceValues = append([]string{"A"}, cardValues...)
+
+// Pool keys of the small and big jokers
+const (
+	smallJokerKey = "BJ"
+	bigJokerKey   = "RJ"
+)
+
+// LegalPlays lists every combination that can be played from the hand: singles, pairs,
+// triples, full houses, straights, tubes, plates, bombs, straight flushes and the joker
+// bomb, with the heart level cards standing in for other cards. Plays of the same cards
+// are listed once, as their strongest combination, and the list is sorted from the
+// weakest play to the strongest.
+func (c *Combiner) LegalPlays(hand *deck.Hand) []LegalPlay {
+	f := newPlayFinder(c, hand.Cards)
+
+	// Singles are every distinct card, wild cards playing as level cards
+	for _, card := range hand.Cards {
+		f.add([]*deck.Card{card}, c.evaluate([]*deck.Card{card}), nil)
+	}
+
+	// Pairs, triples and bombs
+	for _, value := range cardValues {
+		for n := 2; n <= len(f.pools[value])+len(f.wilds); n++ {
+			f.find([]slot{{value, n}})
+		}
+	}
+	for _, joker := range []string{smallJokerKey, bigJokerKey} {
+		f.find([]slot{{joker, 2}})
+	}
+	f.find([]slot{{smallJokerKey, 2}, {bigJokerKey, 2}})
+
+	// Full houses, with a pair of jokers or of any other value
+	for _, three := range cardValues {
+		for _, two := range append(append([]string{}, cardValues...), smallJokerKey, bigJokerKey) {
+			if two != three {
+				f.find([]slot{{three, 3}, {two, 2}})
+			}
+		}
+	}
+
+	// Straights and straight flushes, tubes and plates
+	for _, shape := range []struct{ width, length int }{{1, 5}, {2, 3}, {3, 2}} {
+		for low := 0; low+shape.length <= len(sequenceValues); low++ {
+			slots := make([]slot, shape.length)
+			for i, value := range sequenceValues[low : low+shape.length] {
+				slots[i] = slot{value, shape.width}
+			}
+			f.find(slots)
+		}
+	}
+
+	sort.SliceStable(f.plays, func(i, j int) bool {
+		return c.stronger(f.plays[j].Combination, f.plays[i].Combination)
+	})
+	return f.plays
+}
+
+// LegalPlaysBeating lists the plays from the hand that beat the previous combination,
+// from the weakest to the strongest, as LegalPlays does.
+func (c *Combiner) LegalPlaysBeating(hand *deck.Hand, prev CombinationStrength) []LegalPlay {
+	var plays []LegalPlay
+	for _, play := range c.LegalPlays(hand) {
+		if c.Beats(prev, play.Combination) {
+			plays = append(plays, play)
+		}
+	}
+	return plays
+}
+
+// slot is a number of cards of the same value needed by a combination
+type slot struct {
+	key   string // Card value, or smallJokerKey or bigJokerKey
+	count int
+}
+
+// playFinder collects the combinations of a hand, each set of cards once
+type playFinder struct {
+	combiner *Combiner
+	pools    map[string][]*deck.Card // Cards other than the wild cards by value or joker key
+	wilds    []*deck.Card
+	plays    []LegalPlay
+	index    map[string]int // Index in plays by the cards' key
+}
+
+func newPlayFinder(c *Combiner, cards []*deck.Card) *playFinder {
+	f := &playFinder{
+		combiner: c,
+		pools:    make(map[string][]*deck.Card),
+		index:    make(map[string]int),
+	}
+	for _, card := range cards {
+		switch {
+		case c.IsWild(card):
+			f.wilds = append(f.wilds, card)
+		case card.Value == "Joker" && card.Suit == "Red":
+			f.pools[bigJokerKey] = append(f.pools[bigJokerKey], card)
+		case card.Value == "Joker":
+			f.pools[smallJokerKey] = append(f.pools[smallJokerKey], card)
+		default:
+			f.pools[card.Value] = append(f.pools[card.Value], card)
+		}
+	}
+	for key := range f.pools {
+		pool := f.pools[key]
+		sort.SliceStable(pool, func(i, j int) bool { return pool[i].String() < pool[j].String() })
+	}
+	return f
+}
+
+// find adds every way to fill the slots with the hand's cards, the wild cards
+// filling in for missing cards other than jokers
+func (f *playFinder) find(slots []slot) {
+	var cards []*deck.Card
+	var wildSlots []string // Value each wild card in cards stands in for, in order
+	var fill func(n, wildsLeft int)
+	fill = func(n, wildsLeft int) {
+		if n == len(slots) {
+			f.addFilled(cards, wildSlots)
+			return
+		}
+		s := slots[n]
+		pool := f.pools[s.key]
+		maxWilds := min(wildsLeft, s.count)
+		if s.key == smallJokerKey || s.key == bigJokerKey {
+			maxWilds = 0
+		}
+		for wilds := 0; wilds <= maxWilds; wilds++ {
+			for _, natural := range distinctSubsets(pool, s.count-wilds) {
+				size, wildSize := len(cards), len(wildSlots)
+				cards = append(cards, natural...)
+				for i := 0; i < wilds; i++ {
+					cards = append(cards, f.wilds[len(f.wilds)-wildsLeft+i])
+					wildSlots = append(wildSlots, s.key)
+				}
+				fill(n+1, wildsLeft-wilds)
+				cards, wildSlots = cards[:size], wildSlots[:wildSize]
+			}
+		}
+	}
+	fill(0, len(f.wilds))
+}
+
+// addFilled adds the filled cards, with the wild cards in them standing in for the
+// values of their slots. A wild card takes the suit of the other cards when they
+// share one, so that it can complete a straight flush; in a slot of the level value
+// it plays as itself otherwise.
+func (f *playFinder) addFilled(cards []*deck.Card, wildSlots []string) {
+	suit := ""
+	for _, card := range cards {
+		if f.combiner.IsWild(card) || card.Value == "Joker" {
+			continue
+		}
+		if suit == "" {
+			suit = card.Suit
+		} else if suit != card.Suit {
+			suit = "mixed"
+		}
+	}
+
+	substituted := make([]*deck.Card, len(cards))
+	var substitutions []Substitution
+	w := 0
+	for i, card := range cards {
+		substituted[i] = card
+		if !f.combiner.IsWild(card) {
+			continue
+		}
+		value := wildSlots[w]
+		w++
+		as := deck.NewCard(value, suit)
+		switch {
+		case suit == "" || suit == "mixed":
+			if value == card.Value {
+				continue
+			}
+			as.Suit = "♠"
+		case value == card.Value && suit == card.Suit:
+			continue
+		}
+		substituted[i] = as
+		substitutions = append(substitutions, Substitution{Wild: card, As: as})
+	}
+
+	strength := f.combiner.evaluate(substituted)
+	f.add(cards, strength, substitutions)
+}
+
+// add adds the play, or replaces the play of the same cards if it is stronger
+func (f *playFinder) add(cards []*deck.Card, strength CombinationStrength, substitutions []Substitution) {
+	if strength.Type == InvalidCombination {
+		return
+	}
+	strength.Substitutions = substitutions
+	key := cardsKey(cards)
+	if i, ok := f.index[key]; ok {
+		if f.combiner.stronger(strength, f.plays[i].Combination) {
+			f.plays[i].Combination = strength
+		}
+		return
+	}
+	f.index[key] = len(f.plays)
+	f.plays = append(f.plays, LegalPlay{
+		Cards:       append([]*deck.Card{}, cards...),
+		Combination: strength,
+	})
+}
+
+// distinctSubsets returns the subsets of n cards of the sorted pool, counting cards
+// with the same value and suit from different decks as one
+func distinctSubsets(pool []*deck.Card, n int) [][]*deck.Card {
+	if n == 0 {
+		return [][]*deck.Card{nil}
+	}
+	var subsets [][]*deck.Card
+	var subset []*deck.Card
+	var pick func(start int)
+	pick = func(start int) {
+		if len(subset) == n {
+			subsets = append(subsets, append([]*deck.Card{}, subset...))
+			return
+		}
+		for i := start; i < len(pool); i++ {
+			if i > start && pool[i].String() == pool[i-1].String() {
+				continue
+			}
+			subset = append(subset, pool[i])
+			pick(i + 1)
+			subset = subset[:len(subset)-1]
+		}
+	}
+	pick(0)
+	return subsets
+}
+
+// cardsKey identifies a set of cards by their values and suits, in any order
+func cardsKey(cards []*deck.Card) string {
+	names := make([]string, len(cards))
+	for i, card := range cards {
+		names[i] = card.String()
+	}
+	sort.Strings(names)
+	return strings.Join(names, " ")
+}
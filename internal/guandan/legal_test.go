@@ -0,0 +1,145 @@
+package guandan
+
+import (
+	"testing"
+
+	"github.com/genewoo/joker/internal/deck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type LegalPlaysTestSuite struct {
+	suite.Suite
+	combiner *Combiner
+}
+
+func (suite *LegalPlaysTestSuite) SetupTest() {
+	suite.combiner = NewCombiner("5")
+}
+
+func TestLegalPlaysSuite(t *testing.T) {
+	suite.Run(t, new(LegalPlaysTestSuite))
+}
+
+func (suite *LegalPlaysTestSuite) hand(notation string) *deck.Hand {
+	cards, err := deck.ParseCards(notation)
+	suite.Require().NoError(err)
+	return deck.NewHand(cards...)
+}
+
+// keys returns the plays' cards in their order, each play sorted
+func keys(plays []LegalPlay) []string {
+	keys := make([]string, len(plays))
+	for i, play := range plays {
+		keys[i] = cardsKey(play.Cards)
+	}
+	return keys
+}
+
+func (suite *LegalPlaysTestSuite) TestLegalPlays() {
+	tests := []struct {
+		name     string
+		hand     string
+		expected []string
+	}{
+		{name: "Singles and a pair", hand: "3s 3d 4s", expected: []string{"3♠", "3♦", "4♠", "3♠ 3♦"}},
+		{name: "Cards from two decks count once", hand: "3s 3s", expected: []string{"3♠", "3♠ 3♠"}},
+		{name: "Jokers", hand: "BJ BJ RJ", expected: []string{"JokerBW", "JokerRed", "JokerBW JokerBW"}},
+		{
+			name:     "Wild card completes pairs and a triple",
+			hand:     "7s 7d 5h",
+			expected: []string{"7♠", "7♦", "5♥", "7♠ 7♦", "5♥ 7♠", "5♥ 7♦", "5♥ 7♠ 7♦"},
+		},
+		{
+			name: "Full house with a pair of jokers",
+			hand: "9s 9d 9c BJ BJ",
+			expected: []string{
+				"9♠", "9♦", "9♣", "JokerBW",
+				"9♠ 9♦", "9♠ 9♣", "9♣ 9♦", "JokerBW JokerBW",
+				"9♠ 9♣ 9♦", "9♠ 9♣ 9♦ JokerBW JokerBW",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			plays := suite.combiner.LegalPlays(suite.hand(tt.hand))
+			assert.ElementsMatch(suite.T(), tt.expected, keys(plays))
+		})
+	}
+}
+
+func (suite *LegalPlaysTestSuite) TestSequencesAndBombs() {
+	plays := suite.combiner.LegalPlays(suite.hand("As 2s 3s 4s 5h Kd Qd Jd 10d BJ BJ RJ RJ"))
+	found := make(map[string]CombinationStrength)
+	for _, play := range plays {
+		found[cardsKey(play.Cards)] = play.Combination
+	}
+
+	// The wild card completes the Ace-low straight flush as the 5 of spades
+	lowFlush := found["2♠ 3♠ 4♠ 5♥ A♠"]
+	assert.Equal(suite.T(), StraightFlush, lowFlush.Type)
+	assert.Equal(suite.T(), []int{5, 4, 3, 2, 1}, lowFlush.Values)
+	suite.Require().Len(lowFlush.Substitutions, 1)
+	assert.Equal(suite.T(), "5♠", lowFlush.Substitutions[0].As.String())
+
+	// And the Ace-high straight with diamonds, and the straight flush with the 9 of diamonds
+	assert.Equal(suite.T(), Straight, found["10♦ A♠ J♦ K♦ Q♦"].Type)
+	assert.Equal(suite.T(), StraightFlush, found["10♦ 5♥ J♦ K♦ Q♦"].Type)
+	assert.Equal(suite.T(), JokerBomb, found["JokerBW JokerBW JokerRed JokerRed"].Type)
+	assert.Equal(suite.T(), JokerBomb, plays[len(plays)-1].Combination.Type, "The strongest play comes last")
+}
+
+func (suite *LegalPlaysTestSuite) TestLegalPlaysBeating() {
+	hand := suite.hand("3s 3d 9s 9d 4s 4d 4c 4h")
+	prev := suite.combiner.EvaluateCombination(suite.hand("8s 8d").Cards)
+
+	plays := suite.combiner.LegalPlaysBeating(hand, prev)
+	assert.Equal(suite.T(), []string{"9♠ 9♦", "4♠ 4♣ 4♥ 4♦"}, keys(plays))
+	for _, play := range plays {
+		assert.True(suite.T(), suite.combiner.Beats(prev, play.Combination))
+	}
+}
+
+func (suite *LegalPlaysTestSuite) TestDealtHand() {
+	game := NewGame([4]int{1, 2, 3, 4}, [2]string{"5", "5"})
+	game.Seed(7)
+	game.DealCards()
+
+	for seat := 1; seat <= 4; seat++ {
+		hand := game.players[seat-1].hand
+		plays := suite.combiner.LegalPlays(hand)
+		suite.Require().NotEmpty(plays)
+
+		seen := make(map[string]bool)
+		for i, play := range plays {
+			key := cardsKey(play.Cards)
+			assert.False(suite.T(), seen[key], "%s is listed once", key)
+			seen[key] = true
+
+			// Every play is the combination the game evaluates when it is played
+			evaluated := suite.combiner.EvaluateCombination(play.Cards)
+			assert.Equal(suite.T(), evaluated.Type, play.Combination.Type, key)
+			assert.Equal(suite.T(), suite.combiner.mainRank(evaluated), suite.combiner.mainRank(play.Combination), key)
+			_, ok := findCards(hand, play.Cards)
+			assert.True(suite.T(), ok, key)
+
+			if i > 0 {
+				assert.False(suite.T(), suite.combiner.stronger(plays[i-1].Combination, play.Combination), "plays are sorted from the weakest")
+			}
+		}
+	}
+}
+
+func BenchmarkLegalPlays(b *testing.B) {
+	game := NewGame([4]int{1, 2, 3, 4}, [2]string{"5", "5"})
+	game.Seed(7)
+	game.DealCards()
+	combiner := NewCombiner("5")
+	hand := game.players[0].hand
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		combiner.LegalPlays(hand)
+	}
+}
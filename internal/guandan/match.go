@@ -0,0 +1,245 @@
+package guandan
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// ErrMatchOver is returned when a deal is started or recorded after a team has won the match
+var ErrMatchOver = errors.New("the match is over")
+
+// levels are the team levels in order, from the first deal's level to the last
+var levels = []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
+
+// maxAttemptsAtA is the number of deals a team may fail at level A before it starts again from 2
+const maxAttemptsAtA = 3
+
+// teamNames are the names of the teams by index: A (seats 1, 3) and B (seats 2, 4)
+var teamNames = [2]string{"A", "B"}
+
+// Match is a series of deals played until a team wins a deal at level A.
+// The winners of each deal advance 3 levels when their partner finished second,
+// 2 when third and 1 when last. A team at level A must win a deal played at A with
+// their partner not last to win the match; after failing three such deals they start
+// again from level 2.
+type Match struct {
+	levels      [2]string // Levels of teams A and B
+	attempts    [2]int    // Deals played at A and not won by each team since reaching it
+	lastRanking [4]int
+	game        *Game // Deal being played
+	deals       []DealSummary
+	winner      int         // Index of the team that won the match, or -1
+	source      rand.Source // Source of randomness for shuffling; nil seeds from the current time
+	strategies  [4]Strategy // Strategies playing the seats in every deal
+}
+
+// DealSummary is the outcome of a deal of a match
+type DealSummary struct {
+	Deal      int       // Number of the deal, from 1
+	Level     string    // Level the deal was played at, the level of the declarers
+	Declarers int       // Index of the team that won the last deal: 0 for A, 1 for B
+	Ranking   [4]int    // Seats in the order the players finished
+	Tributes  []Tribute // Tributes paid before the deal
+	Winner    int       // Index of the team that won the deal: 0 for A, 1 for B
+	Advance   int       // Levels the winners advanced
+	Failed    bool      // Whether the declarers failed to win the match at A
+	Reset     bool      // Whether the declarers went back to level 2 after failing at A
+	Levels    [2]string // Levels of teams A and B after the deal
+	MatchWon  bool      // Whether the deal won the match
+}
+
+// String returns a one-line description of the deal, such as
+// "Deal 3 at level 5: 1-3-2-4, team A advances 2 levels (A: 7, B: 2)"
+func (s DealSummary) String() string {
+	ranking := make([]string, len(s.Ranking))
+	for i, seat := range s.Ranking {
+		ranking[i] = fmt.Sprint(seat)
+	}
+	result := fmt.Sprintf("team %s advances %d levels", teamNames[s.Winner], s.Advance)
+	if s.Advance == 1 {
+		result = fmt.Sprintf("team %s advances 1 level", teamNames[s.Winner])
+	}
+	switch {
+	case s.MatchWon:
+		result = fmt.Sprintf("team %s wins the match", teamNames[s.Winner])
+	case s.Reset:
+		result += fmt.Sprintf(", team %s goes back to 2 after %d deals at A", teamNames[s.Declarers], maxAttemptsAtA)
+	case s.Failed:
+		result += fmt.Sprintf(", team %s fails at A", teamNames[s.Declarers])
+	}
+	return fmt.Sprintf("Deal %d at level %s: %s, %s (A: %s, B: %s)",
+		s.Deal, s.Level, strings.Join(ranking, "-"), result, s.Levels[0], s.Levels[1])
+}
+
+// NewMatch creates a match with both teams at level 2
+func NewMatch() *Match {
+	return &Match{
+		levels:      [2]string{"2", "2"},
+		lastRanking: [4]int{1, 2, 3, 4},
+		winner:      -1,
+	}
+}
+
+// SetSource sets the source of randomness used to shuffle the decks of every deal
+func (m *Match) SetSource(src rand.Source) {
+	m.source = src
+}
+
+// Seed makes the hands of every deal reproducible from the given seed
+func (m *Match) Seed(seed int64) {
+	m.SetSource(rand.NewSource(seed))
+}
+
+// SetStrategy sets the strategy playing the seat (1-4) in every deal, or nil for a seat
+// played through Play and Pass. The strategies also pay and return the tributes.
+func (m *Match) SetStrategy(seat int, strategy Strategy) {
+	m.strategies[seat-1] = strategy
+}
+
+// Levels returns the levels of teams A and B
+func (m *Match) Levels() [2]string {
+	return m.levels
+}
+
+// Deals returns the summaries of the deals recorded so far, in order
+func (m *Match) Deals() []DealSummary {
+	return m.deals
+}
+
+// Over reports whether a team has won the match
+func (m *Match) Over() bool {
+	return m.winner >= 0
+}
+
+// Winner returns the index of the team that won the match, 0 for A and 1 for B,
+// or -1 while the match is being played
+func (m *Match) Winner() int {
+	return m.winner
+}
+
+// NextDeal deals the next deal of the match at the level of the last deal's winners,
+// with the ranking of the last deal. The tributes are paid from the second deal on.
+// Play the deal from the game's dealer, then record its ranking with Record.
+// Returns ErrMatchOver once a team has won the match.
+func (m *Match) NextDeal() (*Game, error) {
+	if m.Over() {
+		return nil, ErrMatchOver
+	}
+	game := NewGame(m.lastRanking, m.levels)
+	game.SetSource(m.source)
+	for seat, strategy := range m.strategies {
+		game.SetStrategy(seat+1, strategy)
+	}
+	game.DealCards()
+	if len(m.deals) > 0 {
+		game.SwapCards()
+	}
+	m.game = game
+	return game, nil
+}
+
+// Record records the finishing order of the deal being played, as Game.Ranking returns it,
+// advances the winners' level and carries the ranking into the next deal.
+// Returns an error if the ranking is not an order of the seats 1 to 4.
+func (m *Match) Record(ranking [4]int) (DealSummary, error) {
+	if m.Over() {
+		return DealSummary{}, ErrMatchOver
+	}
+	seen := make(map[int]bool)
+	for _, seat := range ranking {
+		if seat < 1 || seat > 4 || seen[seat] {
+			return DealSummary{}, fmt.Errorf("ranking must be an order of the seats 1 to 4, got %v", ranking)
+		}
+		seen[seat] = true
+	}
+
+	declarers := teamOf(m.lastRanking[0])
+	summary := DealSummary{
+		Deal:      len(m.deals) + 1,
+		Level:     m.levels[declarers],
+		Declarers: declarers,
+		Ranking:   ranking,
+		Winner:    teamOf(ranking[0]),
+	}
+	if m.game != nil {
+		summary.Tributes = m.game.Tributes()
+	}
+
+	partner := 0
+	for i, seat := range ranking {
+		if seat == partnerOf(ranking[0]) {
+			partner = i
+		}
+	}
+	summary.Advance = 4 - partner
+
+	// The declarers play at A for the match: a win with the partner not last takes it
+	if m.levels[declarers] == "A" {
+		if summary.Winner == declarers && summary.Advance > 1 {
+			summary.MatchWon = true
+			m.winner = declarers
+		} else {
+			summary.Failed = true
+			m.attempts[declarers]++
+		}
+	}
+	if !summary.MatchWon {
+		m.levels[summary.Winner] = advanceLevel(m.levels[summary.Winner], summary.Advance)
+		if m.attempts[declarers] == maxAttemptsAtA {
+			m.levels[declarers] = "2"
+			m.attempts[declarers] = 0
+			summary.Reset = true
+		}
+	}
+
+	summary.Levels = m.levels
+	m.lastRanking = ranking
+	m.game = nil
+	m.deals = append(m.deals, summary)
+	return summary, nil
+}
+
+// Run plays deal after deal until a team wins the match. play must play each deal to its
+// end, for example from the game's dealer with StartPlay, Play and Pass; report, if not nil,
+// is called with the summary of each deal.
+// Returns the first error of play, or of recording a deal's ranking.
+func (m *Match) Run(play func(game *Game) error, report func(summary DealSummary)) error {
+	for !m.Over() {
+		game, err := m.NextDeal()
+		if err != nil {
+			return err
+		}
+		if err := play(game); err != nil {
+			return fmt.Errorf("deal %d: %w", len(m.deals)+1, err)
+		}
+		ranking, err := game.Ranking()
+		if err != nil {
+			return fmt.Errorf("deal %d: %w", len(m.deals)+1, err)
+		}
+		summary, err := m.Record(ranking)
+		if err != nil {
+			return err
+		}
+		if report != nil {
+			report(summary)
+		}
+	}
+	return nil
+}
+
+// teamOf returns the index of the seat's team: 0 for A (seats 1, 3) and 1 for B (seats 2, 4)
+func teamOf(seat int) int {
+	return (seat - 1) % 2
+}
+
+// advanceLevel returns the level n levels above the given one, up to A
+func advanceLevel(level string, n int) string {
+	for i, l := range levels {
+		if l == level {
+			return levels[min(i+n, len(levels)-1)]
+		}
+	}
+	return level
+}
@@ -0,0 +1,172 @@
+package guandan
+
+import (
+	"testing"
+
+	"github.com/genewoo/joker/internal/deck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type MatchTestSuite struct {
+	suite.Suite
+}
+
+func TestMatchSuite(t *testing.T) {
+	suite.Run(t, new(MatchTestSuite))
+}
+
+// record records the rankings, failing the test on an error, and returns the last summary
+func (suite *MatchTestSuite) record(match *Match, rankings ...[4]int) DealSummary {
+	var summary DealSummary
+	for _, ranking := range rankings {
+		var err error
+		summary, err = match.Record(ranking)
+		suite.Require().NoError(err)
+	}
+	return summary
+}
+
+func (suite *MatchTestSuite) TestAdvance() {
+	tests := []struct {
+		name     string
+		ranking  [4]int
+		advance  int
+		expected [2]string
+	}{
+		{name: "Partner second", ranking: [4]int{1, 3, 2, 4}, advance: 3, expected: [2]string{"5", "2"}},
+		{name: "Partner third", ranking: [4]int{1, 2, 3, 4}, advance: 2, expected: [2]string{"4", "2"}},
+		{name: "Partner last", ranking: [4]int{1, 2, 4, 3}, advance: 1, expected: [2]string{"3", "2"}},
+		{name: "Team B wins", ranking: [4]int{4, 2, 1, 3}, advance: 3, expected: [2]string{"2", "5"}},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			match := NewMatch()
+			summary := suite.record(match, tt.ranking)
+			assert.Equal(suite.T(), tt.advance, summary.Advance)
+			assert.Equal(suite.T(), tt.expected, summary.Levels)
+			assert.Equal(suite.T(), tt.expected, match.Levels())
+			assert.Equal(suite.T(), "2", summary.Level)
+			assert.False(suite.T(), match.Over())
+		})
+	}
+
+	suite.Run("Up to A", func() {
+		match := NewMatch()
+		match.levels = [2]string{"Q", "2"}
+		summary := suite.record(match, [4]int{1, 3, 2, 4})
+		assert.Equal(suite.T(), [2]string{"A", "2"}, summary.Levels)
+		assert.False(suite.T(), match.Over(), "Reaching A does not win the match")
+	})
+
+	suite.Run("Invalid ranking", func() {
+		_, err := NewMatch().Record([4]int{1, 1, 2, 3})
+		assert.Error(suite.T(), err)
+	})
+}
+
+func (suite *MatchTestSuite) TestMustPlayA() {
+	suite.Run("Win at A", func() {
+		match := NewMatch()
+		match.levels = [2]string{"A", "K"}
+		summary := suite.record(match, [4]int{3, 2, 1, 4})
+		assert.True(suite.T(), summary.MatchWon)
+		assert.True(suite.T(), match.Over())
+		assert.Equal(suite.T(), 0, match.Winner())
+		assert.Equal(suite.T(), "Deal 1 at level A: 3-2-1-4, team A wins the match (A: A, B: K)", summary.String())
+
+		_, err := match.Record([4]int{1, 2, 3, 4})
+		assert.ErrorIs(suite.T(), err, ErrMatchOver)
+		_, err = match.NextDeal()
+		assert.ErrorIs(suite.T(), err, ErrMatchOver)
+	})
+
+	suite.Run("Win with the partner last", func() {
+		match := NewMatch()
+		match.levels = [2]string{"A", "K"}
+		summary := suite.record(match, [4]int{1, 2, 4, 3})
+		assert.False(suite.T(), match.Over())
+		assert.True(suite.T(), summary.Failed)
+		assert.Equal(suite.T(), [2]string{"A", "K"}, summary.Levels)
+	})
+
+	suite.Run("Win at the other team's level", func() {
+		match := NewMatch()
+		match.levels = [2]string{"A", "K"}
+		match.lastRanking = [4]int{2, 1, 3, 4}
+		summary := suite.record(match, [4]int{1, 3, 2, 4})
+		assert.Equal(suite.T(), "K", summary.Level)
+		assert.False(suite.T(), summary.MatchWon, "A must be played to win")
+		assert.False(suite.T(), match.Over())
+
+		// The next deal is played at A
+		summary = suite.record(match, [4]int{3, 1, 2, 4})
+		assert.Equal(suite.T(), "A", summary.Level)
+		assert.True(suite.T(), match.Over())
+	})
+
+	suite.Run("Three failures at A", func() {
+		match := NewMatch()
+		match.levels = [2]string{"A", "K"}
+		summary := suite.record(match, [4]int{1, 2, 4, 3}, [4]int{2, 1, 4, 3})
+		assert.True(suite.T(), summary.Failed)
+		assert.Equal(suite.T(), [2]string{"A", "A"}, summary.Levels)
+
+		// Team B is at A now and plays the next deal there, which team A fails to take back
+		summary = suite.record(match, [4]int{1, 3, 2, 4})
+		assert.True(suite.T(), summary.Failed)
+		assert.Equal(suite.T(), 1, summary.Declarers)
+		assert.Equal(suite.T(), [2]string{"A", "A"}, summary.Levels)
+
+		// Team A fails its third deal at A and starts again from 2
+		summary = suite.record(match, [4]int{1, 2, 4, 3})
+		assert.True(suite.T(), summary.Reset)
+		assert.Equal(suite.T(), [2]string{"2", "A"}, summary.Levels)
+		assert.Equal(suite.T(), "Deal 4 at level A: 1-2-4-3, team A advances 1 level, team A goes back to 2 after 3 deals at A (A: 2, B: A)", summary.String())
+	})
+}
+
+func (suite *MatchTestSuite) TestRun() {
+	// Seat 1 always leads and plays out their hand one card at a time while everyone
+	// else passes, then seat 3 follows the wind: team A wins 1-3-2-4 every deal
+	play := func(game *Game) error {
+		if err := game.StartPlay(1); err != nil {
+			return err
+		}
+		for !game.Finished() {
+			seat := game.Turn()
+			if game.Trick() != nil {
+				if err := game.Pass(seat); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := game.Play(seat, []*deck.Card{game.Hand(seat).Cards[0]}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	match := NewMatch()
+	match.Seed(42)
+	var reported []DealSummary
+	err := match.Run(play, func(summary DealSummary) {
+		reported = append(reported, summary)
+	})
+	suite.Require().NoError(err)
+
+	assert.True(suite.T(), match.Over())
+	assert.Equal(suite.T(), 0, match.Winner())
+	assert.Equal(suite.T(), match.Deals(), reported)
+
+	var played []string
+	for _, summary := range reported {
+		played = append(played, summary.Level)
+		assert.Equal(suite.T(), [4]int{1, 3, 2, 4}, summary.Ranking)
+	}
+	assert.Equal(suite.T(), []string{"2", "5", "8", "J", "A"}, played)
+	assert.Empty(suite.T(), reported[0].Tributes, "No tributes are paid before the first deal")
+	assert.True(suite.T(), reported[4].MatchWon)
+}
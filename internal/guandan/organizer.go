@@ -0,0 +1,33 @@
+package guandan
+
+import (
+	"sort"
+
+	"github.com/genewoo/joker/internal/deck"
+)
+
+// LevelOrganizer sorts cards in Guandan order at a level, highest first: the big joker,
+// the small joker, the level cards, then A down to 2. Cards of the same rank are sorted
+// by suit as deck.DefaultOrganizer does.
+type LevelOrganizer struct {
+	combiner *Combiner
+}
+
+// guandanSuitOrder orders the suits of cards of the same rank
+var guandanSuitOrder = map[string]int{"♠": 4, "♥": 3, "♦": 2, "♣": 1}
+
+// NewLevelOrganizer creates a LevelOrganizer for the level
+func NewLevelOrganizer(level string) *LevelOrganizer {
+	return &LevelOrganizer{combiner: NewCombiner(level)}
+}
+
+// Sort implements the deck.Organizer interface
+func (o *LevelOrganizer) Sort(cards []*deck.Card) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		ri, rj := o.combiner.Rank(cards[i]), o.combiner.Rank(cards[j])
+		if ri != rj {
+			return ri > rj
+		}
+		return guandanSuitOrder[cards[i].Suit] > guandanSuitOrder[cards[j].Suit]
+	})
+}
@@ -0,0 +1,47 @@
+package guandan
+
+import (
+	"testing"
+
+	"github.com/genewoo/joker/internal/deck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevelOrganizer(t *testing.T) {
+	tests := []struct {
+		name     string
+		level    string
+		cards    string
+		expected string
+	}{
+		{name: "Level 2", level: "2", cards: "3s 2h As RJ BJ 10d", expected: "JokerRed,JokerBW,2♥,A♠,10♦,3♠"},
+		{name: "Level 5", level: "5", cards: "4c 5h Kd 5s 6d 2c", expected: "5♠,5♥,K♦,6♦,4♣,2♣"},
+		{name: "Level A", level: "A", cards: "Kc Ah 10s", expected: "A♥,K♣,10♠"},
+		{name: "Level 10 above A", level: "10", cards: "As 10c Js 9h", expected: "10♣,A♠,J♠,9♥"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cards, err := deck.ParseCards(tt.cards)
+			assert.NoError(t, err)
+			hand := deck.NewHand(cards...)
+			hand.SetOrganizer(NewLevelOrganizer(tt.level))
+			assert.Equal(t, tt.expected, hand.String())
+		})
+	}
+}
+
+func TestDealtHandsSortInLevelOrder(t *testing.T) {
+	game := NewGame([4]int{1, 2, 3, 4}, [2]string{"7", "3"})
+	game.Seed(1)
+	game.DealCards()
+
+	combiner := NewCombiner("7")
+	for seat := 1; seat <= 4; seat++ {
+		hand := game.Hand(seat)
+		hand.Sort()
+		for i := 1; i < hand.Count(); i++ {
+			assert.GreaterOrEqual(t, combiner.Rank(hand.Cards[i-1]), combiner.Rank(hand.Cards[i]))
+		}
+	}
+}
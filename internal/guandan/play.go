@@ -0,0 +1,288 @@
+package guandan
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/genewoo/joker/internal/deck"
+)
+
+// Errors returned when a play or pass is not allowed
+var (
+	ErrNoDeal             = errors.New("no deal is being played")
+	ErrDealOver           = errors.New("the deal is over")
+	ErrNotYourTurn        = errors.New("it is not the player's turn")
+	ErrInvalidCombination = errors.New("cards do not form a valid combination")
+	ErrDoesNotBeat        = errors.New("combination does not beat the current trick")
+	ErrCannotPass         = errors.New("the leader of a trick cannot pass")
+	ErrCardsNotInHand     = errors.New("cards are not in the player's hand")
+	ErrDealNotOver        = errors.New("the deal is not over")
+)
+
+// Play is a player's turn in a trick: the cards played, or a pass when there are none
+type Play struct {
+	Seat        int
+	Cards       []*deck.Card
+	Combination CombinationStrength
+}
+
+// IsPass reports whether the player passed
+func (p Play) IsPass() bool {
+	return len(p.Cards) == 0
+}
+
+// StartPlay starts playing the dealt hands, with the given seat (1-4) leading the first trick.
+// Returns an error if the hands have not been dealt or the seat is unknown.
+func (g *Game) StartPlay(leader int) error {
+	if leader < 1 || leader > 4 {
+		return fmt.Errorf("leader must be a seat from 1 to 4, got %d", leader)
+	}
+	for _, p := range g.players {
+		if p.hand == nil || p.hand.Count() == 0 {
+			return fmt.Errorf("player %d has no cards to play", p.seat)
+		}
+	}
+
+	g.combiner = NewCombiner(g.currentLevel)
+	g.turn = leader
+	g.trick = nil
+	g.passes = 0
+	g.finished = nil
+	g.plays = nil
+	g.ranking = [4]int{}
+	return nil
+}
+
+// Hand returns the hand of the player at the seat (1-4)
+func (g *Game) Hand(seat int) *deck.Hand {
+	return g.players[seat-1].hand
+}
+
+// Turn returns the seat (1-4) of the player to play, or 0 if no deal is being played.
+func (g *Game) Turn() int {
+	return g.turn
+}
+
+// Trick returns the play to beat in the current trick, or nil if the player to play leads a new trick.
+func (g *Game) Trick() *Play {
+	return g.trick
+}
+
+// Plays returns every play and pass of the deal so far, in order
+func (g *Game) Plays() []Play {
+	return g.plays
+}
+
+// LegalPlays lists the plays the seat (1-4) can make from their hand on the current trick:
+// any combination when leading a new trick, or the ones that beat the trick otherwise.
+// Returns nil if no deal is being played.
+func (g *Game) LegalPlays(seat int) []LegalPlay {
+	if g.combiner == nil || g.Finished() {
+		return nil
+	}
+	return g.legalPlays(g.Hand(seat))
+}
+
+// legalPlays lists the plays that can be made from the hand on the current trick
+func (g *Game) legalPlays(hand *deck.Hand) []LegalPlay {
+	if g.trick == nil {
+		return g.combiner.LegalPlays(hand)
+	}
+	return g.combiner.LegalPlaysBeating(hand, g.trick.Combination)
+}
+
+// Play plays the cards from the seat's hand. The leader of a trick may play any valid
+// combination; the other players must beat the current trick.
+// Returns an error if the play is not allowed; the game is unchanged then.
+func (g *Game) Play(seat int, cards []*deck.Card) error {
+	if err := g.checkTurn(seat); err != nil {
+		return err
+	}
+
+	strength := g.combiner.EvaluateCombination(cards)
+	if strength.Type == InvalidCombination {
+		return ErrInvalidCombination
+	}
+	if g.trick != nil && !g.combiner.Beats(g.trick.Combination, strength) {
+		return ErrDoesNotBeat
+	}
+
+	hand := g.players[seat-1].hand
+	indices, ok := findCards(hand, cards)
+	if !ok {
+		return ErrCardsNotInHand
+	}
+	played := make([]*deck.Card, len(indices))
+	for i, index := range indices {
+		played[i] = hand.Cards[index]
+	}
+	for _, card := range played {
+		hand.RemoveCard(hand.IndexOf(card))
+	}
+
+	play := Play{Seat: seat, Cards: played, Combination: strength}
+	g.plays = append(g.plays, play)
+	g.trick = &play
+	g.passes = 0
+
+	if hand.Count() == 0 {
+		g.finished = append(g.finished, seat)
+		if g.dealOver() {
+			g.endDeal()
+			return nil
+		}
+	}
+	g.turn = g.nextActive(seat)
+	return nil
+}
+
+// Pass passes the seat's turn. The trick closes once every other player still holding
+// cards has passed on its last play, and the player who made that play leads the next
+// trick. A player who went out with that play hands the lead to their partner, who
+// "follows the wind", or to the next player if the partner is out too.
+func (g *Game) Pass(seat int) error {
+	if err := g.checkTurn(seat); err != nil {
+		return err
+	}
+	if g.trick == nil {
+		return ErrCannotPass
+	}
+
+	g.plays = append(g.plays, Play{Seat: seat})
+	g.passes++
+
+	// Everyone who could answer the trick has passed
+	answering := 0
+	for s := 1; s <= 4; s++ {
+		if s != g.trick.Seat && !g.isFinished(s) {
+			answering++
+		}
+	}
+	if g.passes < answering {
+		g.turn = g.nextActive(seat)
+		return nil
+	}
+
+	leader := g.trick.Seat
+	if g.isFinished(leader) {
+		if partner := partnerOf(leader); !g.isFinished(partner) {
+			leader = partner
+		} else {
+			leader = g.nextActive(leader)
+		}
+	}
+	g.trick = nil
+	g.passes = 0
+	g.turn = leader
+	return nil
+}
+
+// Finished reports whether the deal is over, once both players of a team have gone out
+func (g *Game) Finished() bool {
+	return g.ranking[0] != 0
+}
+
+// Ranking returns the seats in the order the players finished the deal, the form
+// NewGame takes as lastRanking for the next deal. The players still holding cards when
+// the deal ends are ranked by the fewest cards left, then by seat.
+// Returns ErrDealNotOver while the deal is being played.
+func (g *Game) Ranking() ([4]int, error) {
+	if !g.Finished() {
+		return [4]int{}, ErrDealNotOver
+	}
+	return g.ranking, nil
+}
+
+func (g *Game) checkTurn(seat int) error {
+	if g.Finished() {
+		return ErrDealOver
+	}
+	if g.turn == 0 {
+		return ErrNoDeal
+	}
+	if seat != g.turn {
+		return ErrNotYourTurn
+	}
+	return nil
+}
+
+// dealOver reports whether both players of a team have gone out
+func (g *Game) dealOver() bool {
+	for _, seat := range g.finished {
+		if g.isFinished(partnerOf(seat)) {
+			return true
+		}
+	}
+	return false
+}
+
+// endDeal ranks the players who are still holding cards after the ones who went out
+func (g *Game) endDeal() {
+	ranking := append([]int{}, g.finished...)
+	for len(ranking) < 4 {
+		next := 0
+		for s := 1; s <= 4; s++ {
+			if g.isFinished(s) || containsSeat(ranking, s) {
+				continue
+			}
+			if next == 0 || g.Hand(s).Count() < g.Hand(next).Count() {
+				next = s
+			}
+		}
+		ranking = append(ranking, next)
+	}
+	copy(g.ranking[:], ranking)
+	g.turn = 0
+	g.trick = nil
+}
+
+func (g *Game) isFinished(seat int) bool {
+	return containsSeat(g.finished, seat)
+}
+
+// nextActive returns the next seat after the given one whose player still holds cards
+func (g *Game) nextActive(seat int) int {
+	for i := 1; i <= 4; i++ {
+		next := (seat-1+i)%4 + 1
+		if !g.isFinished(next) {
+			return next
+		}
+	}
+	return 0
+}
+
+// partnerOf returns the seat of the player sitting opposite
+func partnerOf(seat int) int {
+	return (seat+1)%4 + 1
+}
+
+func containsSeat(seats []int, seat int) bool {
+	for _, s := range seats {
+		if s == seat {
+			return true
+		}
+	}
+	return false
+}
+
+// findCards returns the indices of the hand's cards matching the cards by value and suit,
+// each hand card used once, and whether all the cards were found
+func findCards(hand *deck.Hand, cards []*deck.Card) ([]int, bool) {
+	used := make(map[int]bool, len(cards))
+	indices := make([]int, 0, len(cards))
+	for _, card := range cards {
+		found := false
+		for i, c := range hand.Cards {
+			if !used[i] && c.Value == card.Value && c.Suit == card.Suit {
+				used[i] = true
+				indices = append(indices, i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return indices, true
+}
@@ -0,0 +1,133 @@
+package guandan
+
+import (
+	"testing"
+
+	"github.com/genewoo/joker/internal/deck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type PlayTestSuite struct {
+	suite.Suite
+}
+
+func TestPlaySuite(t *testing.T) {
+	suite.Run(t, new(PlayTestSuite))
+}
+
+// newPlayGame creates a game at level 2 with the hands of seats 1-4 and starts the play
+func (suite *PlayTestSuite) newPlayGame(leader int, hands ...string) *Game {
+	game := newDealtGame(suite.T(), "2", hands...)
+	suite.Require().NoError(game.StartPlay(leader))
+	return game
+}
+
+func (suite *PlayTestSuite) play(game *Game, seat int, notation string) {
+	playCards(suite.T(), game, seat, notation)
+}
+
+func (suite *PlayTestSuite) pass(game *Game, seats ...int) {
+	for _, seat := range seats {
+		suite.Require().NoError(game.Pass(seat), "seat %d passes", seat)
+	}
+}
+
+func (suite *PlayTestSuite) TestTeamGoesOutFirst() {
+	game := suite.newPlayGame(1, "3s", "5s 5h 9c", "Kd", "6s 7d")
+
+	_, err := game.Ranking()
+	assert.ErrorIs(suite.T(), err, ErrDealNotOver)
+
+	suite.play(game, 1, "3s")
+	assert.Equal(suite.T(), 2, game.Turn())
+	suite.play(game, 2, "9c")
+	suite.play(game, 3, "Kd")
+
+	assert.True(suite.T(), game.Finished())
+	assert.Equal(suite.T(), 0, game.Turn())
+	ranking, err := game.Ranking()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), [4]int{1, 3, 2, 4}, ranking)
+	assert.Len(suite.T(), game.Plays(), 3)
+
+	// The ranking is the last ranking of the next deal
+	next := NewGame(ranking, [2]string{"3", "2"})
+	assert.Equal(suite.T(), "3", next.currentLevel)
+}
+
+func (suite *PlayTestSuite) TestPartnerFollowsTheWind() {
+	game := suite.newPlayGame(1, "As", "3s 4s", "5h 6h 7h", "8d 9d")
+
+	suite.play(game, 1, "As")
+	suite.pass(game, 2, 3, 4)
+	assert.Nil(suite.T(), game.Trick())
+	assert.Equal(suite.T(), 3, game.Turn(), "the partner of the player who went out leads")
+
+	suite.play(game, 3, "5h")
+	suite.play(game, 4, "9d")
+	assert.Equal(suite.T(), 2, game.Turn(), "seat 1 is out and skipped")
+	suite.pass(game, 2, 3)
+	assert.Equal(suite.T(), 4, game.Turn())
+
+	suite.play(game, 4, "8d")
+	suite.pass(game, 2, 3)
+	assert.Equal(suite.T(), 2, game.Turn())
+
+	suite.play(game, 2, "3s")
+	suite.play(game, 3, "6h")
+	suite.pass(game, 2)
+	assert.Equal(suite.T(), 3, game.Turn())
+	suite.play(game, 3, "7h")
+
+	ranking, err := game.Ranking()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), [4]int{1, 4, 3, 2}, ranking)
+}
+
+func (suite *PlayTestSuite) TestIllegalPlays() {
+	game := suite.newPlayGame(1, "3s 4s 6d 6c", "5s 5h 9c", "4h Kd", "6s 7d")
+	cards := func(notation string) []*deck.Card {
+		c, _ := deck.ParseCards(notation)
+		return c
+	}
+
+	assert.ErrorIs(suite.T(), game.Play(2, cards("9c")), ErrNotYourTurn)
+	assert.ErrorIs(suite.T(), game.Pass(1), ErrCannotPass)
+	assert.ErrorIs(suite.T(), game.Play(1, cards("3s 4s")), ErrInvalidCombination)
+	assert.ErrorIs(suite.T(), game.Play(1, cards("As")), ErrCardsNotInHand)
+	assert.ErrorIs(suite.T(), game.Play(1, cards("3s 3s")), ErrCardsNotInHand)
+
+	suite.play(game, 1, "6d 6c")
+	assert.ErrorIs(suite.T(), game.Play(2, cards("9c")), ErrDoesNotBeat, "a single cannot answer a pair")
+	assert.ErrorIs(suite.T(), game.Play(2, cards("5s 5h")), ErrDoesNotBeat)
+	assert.Equal(suite.T(), 3, game.Hand(2).Count(), "a rejected play leaves the hand unchanged")
+
+	unstarted := NewGame([4]int{1, 2, 3, 4}, [2]string{"2", "2"})
+	assert.ErrorIs(suite.T(), unstarted.Pass(1), ErrNoDeal)
+	assert.Error(suite.T(), unstarted.StartPlay(1), "no hands have been dealt")
+	assert.Error(suite.T(), game.StartPlay(5))
+}
+
+func (suite *PlayTestSuite) TestWildCardPlay() {
+	game := suite.newPlayGame(1, "Ks 2h 3c", "As Ad", "4s", "6s")
+
+	suite.play(game, 1, "Ks 2h")
+	assert.Equal(suite.T(), Pair, game.Trick().Combination.Type)
+	assert.Equal(suite.T(), "K♠", game.Trick().Combination.Substitutions[0].As.String())
+	suite.play(game, 2, "As Ad")
+	assert.Equal(suite.T(), 0, game.Hand(2).Count())
+	assert.Equal(suite.T(), 3, game.Turn())
+}
+
+func (suite *PlayTestSuite) TestLegalPlays() {
+	game := suite.newPlayGame(1, "3s 9s", "4s 8s", "7s Ks", "6s 6d")
+
+	assert.Equal(suite.T(), []string{"3♠", "9♠"}, keys(game.LegalPlays(1)), "The leader may play anything")
+	suite.play(game, 1, "3s")
+	assert.Equal(suite.T(), []string{"4♠", "8♠"}, keys(game.LegalPlays(2)))
+	suite.play(game, 2, "8s")
+	assert.Equal(suite.T(), []string{"K♠"}, keys(game.LegalPlays(3)), "Only plays beating the trick")
+	suite.play(game, 3, "Ks")
+	assert.Empty(suite.T(), game.LegalPlays(4))
+}
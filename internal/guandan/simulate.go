@@ -0,0 +1,260 @@
+package guandan
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Simulator plays complete deals of Guandan between bots to gather statistics on the
+// rules. Each deal is played on its own, from a random last ranking and random team
+// levels from 2 to K, so that the deals can be spread over every CPU.
+type Simulator struct {
+	strategies [4]Strategy
+	workers    int   // Number of deals played at once
+	seed       int64 // Seed of the first deal; deal i is dealt from seed+i
+}
+
+// SimulationStats are the statistics of the deals played by a Simulator
+type SimulationStats struct {
+	Deals          int
+	Wins           [2]int // Deals won by teams A and B
+	LevelsGained   [2]int // Levels teams A and B advanced over all their wins
+	Advances       [4]int // Deals won by advancing 1, 2 or 3 levels, by number of levels
+	Bombs          int    // Bombs played, straight flushes and joker bombs included
+	DealsWithBombs int    // Deals in which at least one bomb was played
+	Tributes       int    // Deals in which a single tribute was paid
+	DoubleTributes int    // Deals in which both losers of the last deal paid a tribute
+	Resisted       int    // Deals in which the payers held both red jokers and paid nothing
+	PayersWon      int    // Deals with tributes won by the team that paid them
+	Plays          int    // Plays made, passes excluded
+	Turns          int    // Plays and passes
+}
+
+// WinRate returns the share of the deals the team (0 for A, 1 for B) won
+func (s SimulationStats) WinRate(team int) float64 {
+	return ratio(s.Wins[team], s.Deals)
+}
+
+// AverageLevelsGained returns the average number of levels the team (0 for A, 1 for B)
+// advanced in the deals it won
+func (s SimulationStats) AverageLevelsGained(team int) float64 {
+	return ratio(s.LevelsGained[team], s.Wins[team])
+}
+
+// BombsPerDeal returns the average number of bombs played in a deal
+func (s SimulationStats) BombsPerDeal() float64 {
+	return ratio(s.Bombs, s.Deals)
+}
+
+// TurnsPerDeal returns the average number of plays and passes of a deal
+func (s SimulationStats) TurnsPerDeal() float64 {
+	return ratio(s.Turns, s.Deals)
+}
+
+// String returns a report of the statistics, one line per figure
+func (s SimulationStats) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Deals: %d\n", s.Deals)
+	for team, name := range teamNames {
+		fmt.Fprintf(&b, "Team %s: %d wins (%.1f%%), %.2f levels gained per win\n",
+			name, s.Wins[team], 100*s.WinRate(team), s.AverageLevelsGained(team))
+	}
+	fmt.Fprintf(&b, "Advances: 3 levels %d, 2 levels %d, 1 level %d\n", s.Advances[3], s.Advances[2], s.Advances[1])
+	fmt.Fprintf(&b, "Bombs: %d (%.2f per deal), in %d deals (%.1f%%)\n",
+		s.Bombs, s.BombsPerDeal(), s.DealsWithBombs, 100*ratio(s.DealsWithBombs, s.Deals))
+	paid := s.Tributes + s.DoubleTributes
+	fmt.Fprintf(&b, "Tributes: %d single, %d double, %d resisted; payers won %d of %d deals (%.1f%%)\n",
+		s.Tributes, s.DoubleTributes, s.Resisted, s.PayersWon, paid, 100*ratio(s.PayersWon, paid))
+	fmt.Fprintf(&b, "Deal length: %.1f turns, %.1f plays", s.TurnsPerDeal(), ratio(s.Plays, s.Deals))
+	return b.String()
+}
+
+// add adds the statistics of other deals
+func (s *SimulationStats) add(other SimulationStats) {
+	s.Deals += other.Deals
+	for team := range s.Wins {
+		s.Wins[team] += other.Wins[team]
+		s.LevelsGained[team] += other.LevelsGained[team]
+	}
+	for n := range s.Advances {
+		s.Advances[n] += other.Advances[n]
+	}
+	s.Bombs += other.Bombs
+	s.DealsWithBombs += other.DealsWithBombs
+	s.Tributes += other.Tributes
+	s.DoubleTributes += other.DoubleTributes
+	s.Resisted += other.Resisted
+	s.PayersWon += other.PayersWon
+	s.Plays += other.Plays
+	s.Turns += other.Turns
+}
+
+// NewSimulator creates a simulator with a HeuristicBot in every seat, playing as many
+// deals at once as there are CPUs
+func NewSimulator() *Simulator {
+	s := &Simulator{
+		workers: runtime.NumCPU(),
+		seed:    time.Now().UnixNano(),
+	}
+	for seat := 1; seat <= 4; seat++ {
+		s.SetStrategy(seat, HeuristicBot{})
+	}
+	return s
+}
+
+// SetStrategy sets the strategy playing the seat (1-4) in every deal. The deals are
+// played at once, so the strategy must be safe for concurrent use.
+func (s *Simulator) SetStrategy(seat int, strategy Strategy) {
+	s.strategies[seat-1] = strategy
+}
+
+// SetWorkers sets the number of deals played at once; 1 plays them one after the other
+func (s *Simulator) SetWorkers(workers int) {
+	s.workers = max(workers, 1)
+}
+
+// Seed makes the deals reproducible from the given seed: deal i is dealt from seed+i,
+// whatever the number of workers
+func (s *Simulator) Seed(seed int64) {
+	s.seed = seed
+}
+
+// Run plays the number of deals and returns their statistics.
+// Returns the error of the first deal a strategy could not play to its end.
+func (s *Simulator) Run(deals int) (SimulationStats, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		stats    SimulationStats
+		firstErr error
+	)
+	dealCh := make(chan int, s.workers)
+	for w := 0; w < s.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var local SimulationStats
+			var localErr error
+			for deal := range dealCh {
+				if localErr != nil {
+					continue
+				}
+				localErr = s.playDeal(deal, &local)
+			}
+
+			mu.Lock()
+			stats.add(local)
+			if localErr != nil && firstErr == nil {
+				firstErr = localErr
+			}
+			mu.Unlock()
+		}()
+	}
+	for deal := 0; deal < deals; deal++ {
+		dealCh <- deal
+	}
+	close(dealCh)
+	wg.Wait()
+
+	return stats, firstErr
+}
+
+// playDeal deals the deal from its seed, pays the tributes, plays it with the
+// strategies, advances the winners' level and adds its outcome to the statistics
+func (s *Simulator) playDeal(deal int, stats *SimulationStats) error {
+	rng := rand.New(rand.NewSource(s.seed + int64(deal)))
+	var lastRanking [4]int
+	for i, seat := range rng.Perm(4) {
+		lastRanking[i] = seat + 1
+	}
+	teamLevels := [2]string{levels[rng.Intn(len(levels)-1)], levels[rng.Intn(len(levels)-1)]}
+
+	game := NewGame(lastRanking, teamLevels)
+	game.SetSource(rng)
+	for seat, strategy := range s.strategies {
+		game.SetStrategy(seat+1, strategy)
+	}
+	game.DealCards()
+	paid := game.SwapCards()
+	if err := game.StartPlay(game.Dealer()); err != nil {
+		return fmt.Errorf("deal %d: %w", deal, err)
+	}
+	for !game.Finished() {
+		if err := game.PlayTurn(); err != nil {
+			return fmt.Errorf("deal %d: %w", deal, err)
+		}
+	}
+	ranking, err := game.Ranking()
+	if err != nil {
+		return fmt.Errorf("deal %d: %w", deal, err)
+	}
+
+	winner := teamOf(ranking[0])
+	stats.Deals++
+	stats.Wins[winner]++
+
+	advance := 0
+	for i, seat := range ranking {
+		if seat == partnerOf(ranking[0]) {
+			advance = 4 - i
+		}
+	}
+	stats.Advances[advance]++
+	team := game.teams[winner]
+	before := levelIndex(team.level)
+	for n := 0; n < advance; n++ {
+		game.NextLevel(team)
+	}
+	stats.LevelsGained[winner] += levelIndex(team.level) - before
+
+	tributes := game.Tributes()
+	switch {
+	case !paid:
+		stats.Resisted++
+	case len(tributes) == 2:
+		stats.DoubleTributes++
+	default:
+		stats.Tributes++
+	}
+	if paid && teamOf(tributes[0].Giver) == winner {
+		stats.PayersWon++
+	}
+
+	bombs := 0
+	for _, play := range game.Plays() {
+		stats.Turns++
+		if !play.IsPass() {
+			stats.Plays++
+		}
+		if isBomb(play.Combination) {
+			bombs++
+		}
+	}
+	stats.Bombs += bombs
+	if bombs > 0 {
+		stats.DealsWithBombs++
+	}
+	return nil
+}
+
+// levelIndex returns the index of the level among levels, from 0 for 2 to 12 for A
+func levelIndex(level string) int {
+	for i, l := range levels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
+
+// ratio returns n divided by total, or 0 when total is 0
+func ratio(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
@@ -0,0 +1,68 @@
+package guandan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type SimulatorTestSuite struct {
+	suite.Suite
+}
+
+func TestSimulatorSuite(t *testing.T) {
+	suite.Run(t, new(SimulatorTestSuite))
+}
+
+func (suite *SimulatorTestSuite) TestRun() {
+	simulator := NewSimulator()
+	simulator.Seed(42)
+	simulator.SetStrategy(2, GreedyBot{})
+	simulator.SetStrategy(4, GreedyBot{})
+
+	stats, err := simulator.Run(12)
+	suite.Require().NoError(err)
+
+	assert.Equal(suite.T(), 12, stats.Deals)
+	assert.Equal(suite.T(), 12, stats.Wins[0]+stats.Wins[1])
+	assert.Equal(suite.T(), 12, stats.Advances[1]+stats.Advances[2]+stats.Advances[3])
+	assert.Equal(suite.T(), 12, stats.Tributes+stats.DoubleTributes+stats.Resisted)
+	assert.LessOrEqual(suite.T(), stats.LevelsGained[0]+stats.LevelsGained[1],
+		stats.Advances[1]+2*stats.Advances[2]+3*stats.Advances[3], "levels stop at A")
+	assert.Positive(suite.T(), stats.LevelsGained[0]+stats.LevelsGained[1])
+	assert.LessOrEqual(suite.T(), stats.DealsWithBombs, stats.Bombs)
+	assert.LessOrEqual(suite.T(), stats.Plays, stats.Turns)
+	assert.GreaterOrEqual(suite.T(), stats.Plays, 12*4, "each player plays at least once")
+	assert.InDelta(suite.T(), 1, stats.WinRate(0)+stats.WinRate(1), 1e-9)
+	assert.Contains(suite.T(), stats.String(), "Deals: 12\n")
+}
+
+func (suite *SimulatorTestSuite) TestReproducible() {
+	run := func(workers int) SimulationStats {
+		simulator := NewSimulator()
+		simulator.Seed(7)
+		simulator.SetWorkers(workers)
+		stats, err := simulator.Run(6)
+		suite.Require().NoError(err)
+		return stats
+	}
+
+	assert.Equal(suite.T(), run(1), run(3), "each deal is dealt from its own seed")
+}
+
+func (suite *SimulatorTestSuite) TestStrategyError() {
+	simulator := NewSimulator()
+	simulator.Seed(1)
+	simulator.SetStrategy(3, nil)
+
+	_, err := simulator.Run(2)
+	assert.ErrorIs(suite.T(), err, ErrNoStrategy)
+}
+
+func (suite *SimulatorTestSuite) TestEmptyStats() {
+	var stats SimulationStats
+	assert.Zero(suite.T(), stats.WinRate(0))
+	assert.Zero(suite.T(), stats.AverageLevelsGained(1))
+	assert.Zero(suite.T(), stats.BombsPerDeal())
+}
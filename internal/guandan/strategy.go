@@ -0,0 +1,244 @@
+package guandan
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/genewoo/joker/internal/deck"
+)
+
+// ErrNoStrategy is returned when a seat without a strategy is asked to play
+var ErrNoStrategy = errors.New("no strategy plays the seat")
+
+// Strategy makes a player's decisions, so that a bot can fill a seat of a game
+type Strategy interface {
+	// ChooseTribute returns the card the giver pays as tribute, one of the eligible
+	// cards of the highest rank; nil or a card that is not eligible pays the first one.
+	ChooseTribute(giver int, eligible []*deck.Card) *deck.Card
+	// ReturnStrategy chooses the card the player returns for a tribute
+	ReturnStrategy
+	// ChoosePlay returns one of the state's legal plays, or nil to pass
+	ChoosePlay(state PlayState) *LegalPlay
+}
+
+// PlayState is what a player knows when it is their turn to play: the view of their
+// seat and the plays they may make from it
+type PlayState struct {
+	PlayerView
+	Legal    []LegalPlay // Plays the player may make from the view's hand, from the weakest
+	Combiner *Combiner   // Combiner of the deal's level
+}
+
+// SetStrategy sets the strategy playing the seat (1-4), or nil for a seat played through
+// Play and Pass. The strategies also choose the tributes and returns of their seats.
+func (g *Game) SetStrategy(seat int, strategy Strategy) {
+	g.strategies[seat-1] = strategy
+}
+
+// PlayTurn plays the turn of the seat to play with its strategy, which only sees the
+// view of the seat.
+// Returns ErrNoStrategy if the seat has no strategy, or the error of an illegal decision.
+func (g *Game) PlayTurn() error {
+	seat := g.turn
+	if seat == 0 {
+		return g.checkTurn(seat)
+	}
+	strategy := g.strategies[seat-1]
+	if strategy == nil {
+		return fmt.Errorf("seat %d: %w", seat, ErrNoStrategy)
+	}
+
+	view := g.View(seat)
+	state := PlayState{
+		PlayerView: view,
+		Legal:      g.legalPlays(deck.NewHand(view.Hand...)),
+		Combiner:   g.combiner,
+	}
+
+	var err error
+	if play := strategy.ChoosePlay(state); play != nil {
+		err = g.Play(seat, play.Cards)
+	} else {
+		err = g.Pass(seat)
+	}
+	if err != nil {
+		return fmt.Errorf("seat %d: %w", seat, err)
+	}
+	return nil
+}
+
+// PlayBots plays the turns of the seats with a strategy until the deal is over or a seat
+// without one is to play. Returns the first error of PlayTurn.
+func (g *Game) PlayBots() error {
+	for !g.Finished() && g.turn != 0 && g.strategies[g.turn-1] != nil {
+		if err := g.PlayTurn(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GreedyBot implements a baseline Strategy that plays as soon as it can: it leads and
+// answers every trick with its weakest legal play, bombs included, and only passes when
+// nothing beats the trick.
+type GreedyBot struct{}
+
+func (GreedyBot) ChooseTribute(giver int, eligible []*deck.Card) *deck.Card {
+	return eligible[0]
+}
+
+func (GreedyBot) ChooseReturn(receiver int, eligible []*deck.Card) *deck.Card {
+	return eligible[len(eligible)-1]
+}
+
+func (GreedyBot) ChoosePlay(state PlayState) *LegalPlay {
+	if len(state.Legal) == 0 {
+		return nil
+	}
+	return &state.Legal[0]
+}
+
+// HeuristicBot implements a Strategy that plays for its team:
+//   - it keeps its bombs for when an opponent is close to going out, or for going out itself
+//   - it does not overtake its partner's play, unless it goes out with its own
+//   - it leads a play that leaves it one combination to go out with, when it has one, or
+//     else the play that sheds the most cards among those of its lowest rank
+//   - it saves the wild cards for bombs, playing other combinations without them when it can
+//   - it returns a card for a tribute that breaks none of its pairs or longer groups
+type HeuristicBot struct {
+	// Threat is the number of cards or fewer an opponent holds for the bot to bomb them;
+	// 0 uses 6
+	Threat int
+}
+
+func (b HeuristicBot) ChooseTribute(giver int, eligible []*deck.Card) *deck.Card {
+	return eligible[0]
+}
+
+// ChooseReturn returns the lowest eligible card whose value the bot holds only once
+func (b HeuristicBot) ChooseReturn(receiver int, eligible []*deck.Card) *deck.Card {
+	count := make(map[string]int)
+	for _, card := range eligible {
+		count[card.Value]++
+	}
+	for i := len(eligible) - 1; i >= 0; i-- {
+		if count[eligible[i].Value] == 1 {
+			return eligible[i]
+		}
+	}
+	return eligible[len(eligible)-1]
+}
+
+func (b HeuristicBot) ChoosePlay(state PlayState) *LegalPlay {
+	// Going out ends the deal for the bot: take it whenever it can
+	for i := range state.Legal {
+		if len(state.Legal[i].Cards) == len(state.Hand) {
+			return &state.Legal[i]
+		}
+	}
+
+	if state.Leading() {
+		return b.lead(state)
+	}
+	if state.Trick.Seat == partnerOf(state.Seat) {
+		return nil
+	}
+
+	threat := b.Threat
+	if threat == 0 {
+		threat = 6
+	}
+	opponentThreatens := state.CardsLeft[state.Trick.Seat-1] <= threat
+
+	var bomb *LegalPlay
+	for i := range state.Legal {
+		play := &state.Legal[i]
+		if isBomb(play.Combination) {
+			if bomb == nil {
+				bomb = play
+			}
+			continue
+		}
+		if len(play.Combination.Substitutions) == 0 || opponentThreatens {
+			return play
+		}
+	}
+	if opponentThreatens {
+		return bomb
+	}
+	return nil
+}
+
+// lead returns a play after which the rest of the hand is a single combination, or the
+// play that sheds the most cards among the plays of the lowest rank, without bombs or
+// wild cards when there are other plays
+func (b HeuristicBot) lead(state PlayState) *LegalPlay {
+	if len(state.Hand) <= maxCombinationSize+1 {
+		for i := range state.Legal {
+			rest := remainingCards(state.Hand, state.Legal[i].Cards)
+			if state.Combiner.EvaluateCombination(rest).Type != InvalidCombination {
+				return &state.Legal[i]
+			}
+		}
+	}
+
+	var best *LegalPlay
+	bestRank := 0
+	for _, allowBombs := range []bool{false, true} {
+		for i := range state.Legal {
+			play := &state.Legal[i]
+			if (!allowBombs && isBomb(play.Combination)) || len(play.Combination.Substitutions) > 0 {
+				continue
+			}
+			rank := lowestRank(state.Combiner, play.Cards)
+			if best == nil || rank < bestRank || (rank == bestRank && len(play.Cards) > len(best.Cards)) {
+				best, bestRank = play, rank
+			}
+		}
+		if best != nil {
+			return best
+		}
+	}
+	return &state.Legal[0]
+}
+
+// maxCombinationSize is the most cards a combination holds: a bomb of eight cards and
+// the two wild cards
+const maxCombinationSize = 10
+
+// remainingCards returns the cards of the hand that are not played
+func remainingCards(hand, played []*deck.Card) []*deck.Card {
+	var rest []*deck.Card
+	for _, card := range hand {
+		if !containsCard(played, card) {
+			rest = append(rest, card)
+		}
+	}
+	return rest
+}
+
+// containsCard reports whether the very card is among the cards
+func containsCard(cards []*deck.Card, card *deck.Card) bool {
+	for _, c := range cards {
+		if c == card {
+			return true
+		}
+	}
+	return false
+}
+
+// isBomb reports whether the combination beats every combination other than bombs
+func isBomb(c CombinationStrength) bool {
+	return bombPower(c) > 0
+}
+
+// lowestRank returns the lowest Guandan rank of the cards
+func lowestRank(combiner *Combiner, cards []*deck.Card) int {
+	lowest := 0
+	for _, card := range cards {
+		if rank := combiner.Rank(card); lowest == 0 || rank < lowest {
+			lowest = rank
+		}
+	}
+	return lowest
+}
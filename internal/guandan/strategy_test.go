@@ -0,0 +1,233 @@
+package guandan
+
+import (
+	"testing"
+
+	"github.com/genewoo/joker/internal/deck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type StrategyTestSuite struct {
+	suite.Suite
+}
+
+func TestStrategySuite(t *testing.T) {
+	suite.Run(t, new(StrategyTestSuite))
+}
+
+// newBotGame creates a game at level 2 with the hands of seats 1-4, played by the
+// strategies, and starts the play
+func (suite *StrategyTestSuite) newBotGame(leader int, strategy Strategy, hands ...string) *Game {
+	game := newDealtGame(suite.T(), "2", hands...)
+	for seat := 1; seat <= 4; seat++ {
+		game.SetStrategy(seat, strategy)
+	}
+	suite.Require().NoError(game.StartPlay(leader))
+	return game
+}
+
+// lastPlay returns the cards of the last play or pass, sorted as a hand
+func (suite *StrategyTestSuite) lastPlay(game *Game) string {
+	plays := game.Plays()
+	suite.Require().NotEmpty(plays)
+	hand := deck.NewHand(plays[len(plays)-1].Cards...)
+	hand.SetOrganizer(NewLevelOrganizer(game.Level()))
+	return hand.String()
+}
+
+func (suite *StrategyTestSuite) TestGreedyBot() {
+	game := suite.newBotGame(1, GreedyBot{}, "3s 3h 9c", "5s 5d Kd", "4c 3c", "7s 8d")
+
+	suite.Require().NoError(game.PlayTurn())
+	assert.Equal(suite.T(), "3♠", suite.lastPlay(game), "leads its weakest play")
+	suite.Require().NoError(game.PlayTurn())
+	assert.Equal(suite.T(), "5♠", suite.lastPlay(game))
+	suite.Require().NoError(game.PlayTurn())
+	assert.True(suite.T(), game.Plays()[2].IsPass(), "passes when nothing beats the trick")
+	suite.Require().NoError(game.PlayTurn())
+	assert.Equal(suite.T(), "7♠", suite.lastPlay(game), "overtakes its partner")
+
+	suite.Require().NoError(game.PlayBots())
+	assert.True(suite.T(), game.Finished())
+}
+
+func (suite *StrategyTestSuite) TestHeuristicBotKeepsBombs() {
+	game := suite.newBotGame(4, HeuristicBot{}, "9s 9h 9c 9d 3h Ks Qs Js Ts", "5s", "4s", "Ad Kd Qd Jd 8d 7c 5c 4c 3c")
+
+	playCards(suite.T(), game, 4, "Ad")
+	suite.Require().NoError(game.PlayTurn())
+	assert.True(suite.T(), game.Plays()[1].IsPass(), "keeps its bomb against an opponent with 8 cards")
+
+	game = suite.newBotGame(4, HeuristicBot{}, "9s 9h 9c 9d 3h Ks Qs Js Ts", "5s", "4s", "Ad Kd 4c")
+	playCards(suite.T(), game, 4, "Ad")
+	suite.Require().NoError(game.PlayTurn())
+	assert.Equal(suite.T(), "9♠,9♥,9♦,9♣", suite.lastPlay(game), "bombs an opponent close to going out")
+}
+
+func (suite *StrategyTestSuite) TestHeuristicBotSupportsPartner() {
+	game := suite.newBotGame(1, HeuristicBot{}, "3s 8h Ks", "4s 4h Kd Qc", "5s 6h 7c", "4d 3d Jc")
+
+	playCards(suite.T(), game, 1, "3s")
+	playCards(suite.T(), game, 2, "4s")
+	suite.Require().NoError(game.PlayTurn())
+	assert.Equal(suite.T(), "5♠", suite.lastPlay(game), "beats an opponent with its lowest card")
+	suite.Require().NoError(game.Pass(4))
+	suite.Require().NoError(game.PlayTurn())
+	last := game.Plays()[len(game.Plays())-1]
+	assert.Equal(suite.T(), 1, last.Seat)
+	assert.True(suite.T(), last.IsPass(), "does not overtake its partner")
+}
+
+func (suite *StrategyTestSuite) TestHeuristicBotLeads() {
+	game := suite.newBotGame(1, HeuristicBot{}, "3s 4h 5c 6d 7s 8d 9c 9d Qs Ks", "As", "Ah", "Ac")
+	suite.Require().NoError(game.PlayTurn())
+	assert.Equal(suite.T(), "7♠,6♦,5♣,4♥,3♠", suite.lastPlay(game), "sheds the most cards of its lowest rank")
+
+	game = suite.newBotGame(1, HeuristicBot{}, "3s 3d 4c 8s 8d 8h", "As", "Ah", "Ac")
+	suite.Require().NoError(game.PlayTurn())
+	assert.Equal(suite.T(), "4♣", suite.lastPlay(game), "leaves a full house to go out with")
+
+	game = suite.newBotGame(1, HeuristicBot{}, "3s 2h 9c 9d Ks Kd Qs", "As", "Ah", "Ac")
+	suite.Require().NoError(game.PlayTurn())
+	assert.Equal(suite.T(), "3♠", suite.lastPlay(game), "saves the wild card rather than pairing its 3")
+}
+
+func (suite *StrategyTestSuite) TestHeuristicBotGoesOut() {
+	game := suite.newBotGame(1, HeuristicBot{}, "3s 4d", "5s 5d", "6s", "7s 8d")
+
+	suite.Require().NoError(game.PlayTurn())
+	assert.Equal(suite.T(), "3♠", suite.lastPlay(game))
+	suite.Require().NoError(game.Pass(2))
+	suite.Require().NoError(game.PlayTurn())
+	assert.Equal(suite.T(), "6♠", suite.lastPlay(game), "goes out over its partner")
+}
+
+func (suite *StrategyTestSuite) TestHeuristicBotReturn() {
+	cards, err := deck.ParseCards("10s 9h 9d 7c 5c 5s")
+	suite.Require().NoError(err)
+	card := HeuristicBot{}.ChooseReturn(1, cards)
+	assert.Equal(suite.T(), "7♣", card.String(), "keeps its pairs")
+
+	cards, err = deck.ParseCards("9h 9d 5c 5s")
+	suite.Require().NoError(err)
+	card = HeuristicBot{}.ChooseReturn(1, cards)
+	assert.Equal(suite.T(), "5♠", card.String())
+}
+
+func (suite *StrategyTestSuite) TestTributeStrategy() {
+	game := newDealtGame(suite.T(), "2", "As Ad 3c", "Kd Kc 4h", "Ks Qh 8c", "Ac Ah 4d 5d")
+	game.SetStrategy(4, chooseSuit("♥"))
+	game.SetStrategy(1, chooseSuit("♥"))
+
+	suite.Require().True(game.SwapCards())
+	tributes := game.Tributes()
+	suite.Require().Len(tributes, 1)
+	assert.Equal(suite.T(), "A♥", tributes[0].Card.String(), "the giver's strategy chooses among its aces")
+	assert.Equal(suite.T(), "3♣", tributes[0].Return.String(), "an unsuited choice returns the lowest card")
+}
+
+func (suite *StrategyTestSuite) TestTributeStrategyFallback() {
+	hands := []string{"As Ad 3c 7d", "Kd Kc 4h", "Ks Qh 8c", "Ac Ah 4d 5d"}
+	unplayed := newDealtGame(suite.T(), "2", hands...)
+	suite.Require().True(unplayed.SwapCards())
+	expected := unplayed.Tributes()[0]
+
+	game := newDealtGame(suite.T(), "2", hands...)
+	game.SetStrategy(4, chooseSuit(""))
+	game.SetStrategy(1, chooseSuit(""))
+	suite.Require().True(game.SwapCards())
+
+	tribute := game.Tributes()[0]
+	assert.Equal(suite.T(), expected.Card.String(), tribute.Card.String(), "no choice pays the same card as a seat without a strategy")
+	assert.Equal(suite.T(), "A♣", tribute.Card.String(), "the first eligible card")
+	assert.Equal(suite.T(), expected.Return.String(), tribute.Return.String())
+	assert.Equal(suite.T(), "3♣", tribute.Return.String(), "the lowest eligible card")
+}
+
+// chooseSuit is a strategy paying and returning the eligible card of the suit
+type chooseSuit string
+
+func (s chooseSuit) ChooseTribute(giver int, eligible []*deck.Card) *deck.Card {
+	return s.ChooseReturn(giver, eligible)
+}
+
+func (s chooseSuit) ChooseReturn(receiver int, eligible []*deck.Card) *deck.Card {
+	for _, card := range eligible {
+		if card.Suit == string(s) {
+			return card
+		}
+	}
+	return nil
+}
+
+func (chooseSuit) ChoosePlay(state PlayState) *LegalPlay {
+	return nil
+}
+
+func (suite *StrategyTestSuite) TestPlayTurnWithoutStrategy() {
+	game := suite.newBotGame(1, GreedyBot{}, "3s", "5s", "6s", "7s")
+	game.SetStrategy(2, nil)
+
+	suite.Require().NoError(game.PlayBots())
+	assert.Equal(suite.T(), 2, game.Turn(), "stops at the seat played by a person")
+	assert.ErrorIs(suite.T(), game.PlayTurn(), ErrNoStrategy)
+
+	playCards(suite.T(), game, 2, "5s")
+	suite.Require().NoError(game.PlayBots())
+	assert.True(suite.T(), game.Finished())
+}
+
+func (suite *StrategyTestSuite) TestSelfPlay() {
+	var tributes, returns []string
+	bot := recordingBot{tributes: &tributes, returns: &returns}
+	match := NewMatch()
+	match.Seed(7)
+	for seat := 1; seat <= 4; seat++ {
+		match.SetStrategy(seat, bot)
+	}
+
+	paid := 0
+	err := match.Run(func(game *Game) error {
+		if err := game.StartPlay(game.Dealer()); err != nil {
+			return err
+		}
+		return game.PlayBots()
+	}, func(summary DealSummary) {
+		assert.ElementsMatch(suite.T(), []int{1, 2, 3, 4}, summary.Ranking[:])
+
+		var paidCards, returnedCards []string
+		for _, tribute := range summary.Tributes {
+			paidCards = append(paidCards, tribute.Card.String())
+			returnedCards = append(returnedCards, tribute.Return.String())
+		}
+		assert.ElementsMatch(suite.T(), tributes, paidCards, "deal %d pays the bots' tributes", summary.Deal)
+		assert.ElementsMatch(suite.T(), returns, returnedCards, "deal %d returns the bots' cards", summary.Deal)
+		paid += len(summary.Tributes)
+		tributes, returns = nil, nil
+	})
+
+	suite.Require().NoError(err)
+	assert.True(suite.T(), match.Over())
+	assert.Greater(suite.T(), len(match.Deals()), 1)
+	assert.Positive(suite.T(), paid, "the bots pay tributes across the deals")
+}
+
+// recordingBot is a HeuristicBot paying the last eligible tribute and returning the
+// highest eligible card, instead of the defaults, and recording its choices
+type recordingBot struct {
+	HeuristicBot
+	tributes, returns *[]string
+}
+
+func (b recordingBot) ChooseTribute(giver int, eligible []*deck.Card) *deck.Card {
+	card := eligible[len(eligible)-1]
+	*b.tributes = append(*b.tributes, card.String())
+	return card
+}
+
+func (b recordingBot) ChooseReturn(receiver int, eligible []*deck.Card) *deck.Card {
+	card := eligible[0]
+	*b.returns = append(*b.returns, card.String())
+	return card
+}
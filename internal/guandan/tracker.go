@@ -0,0 +1,123 @@
+package guandan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/genewoo/joker/internal/deck"
+)
+
+// trackerSuits are the suits of the tracker's columns, in the order cards of a rank are sorted
+var trackerSuits = []string{"♠", "♥", "♦", "♣"}
+
+// Tracker counts the cards a player has not seen yet: the cards of the two decks that
+// are neither in their hand nor played, and so are held by the other three players.
+// A card the player gave as tribute counts as unseen until it is played.
+type Tracker struct {
+	level  string
+	unseen map[deck.Card]int // Copies of each card left unseen, from 0 to 2
+}
+
+// NewTracker counts the cards unseen by the player of the view
+func NewTracker(view PlayerView) *Tracker {
+	t := &Tracker{level: view.Level, unseen: make(map[deck.Card]int)}
+	for _, value := range cardValues {
+		for _, suit := range trackerSuits {
+			t.unseen[deck.Card{Value: value, Suit: suit}] = 2
+		}
+	}
+	t.unseen[deck.Card{Value: "Joker", Suit: "BW"}] = 2
+	t.unseen[deck.Card{Value: "Joker", Suit: "Red"}] = 2
+
+	for _, cards := range [][]*deck.Card{view.Hand, view.PlayedCards()} {
+		for _, card := range cards {
+			if t.unseen[*card] > 0 {
+				t.unseen[*card]--
+			}
+		}
+	}
+	return t
+}
+
+// Tracker returns the tracker of the cards unseen by the player at the seat (1-4)
+func (g *Game) Tracker(seat int) *Tracker {
+	return NewTracker(g.View(seat))
+}
+
+// Count returns the number of copies of the card left unseen; jokers are counted
+// with the value "Joker" and the suit "Red" or "BW"
+func (t *Tracker) Count(value, suit string) int {
+	return t.unseen[deck.Card{Value: value, Suit: suit}]
+}
+
+// CountValue returns the number of cards of the value left unseen, of every suit
+func (t *Tracker) CountValue(value string) int {
+	if value == "Joker" {
+		return t.Count(value, "BW") + t.Count(value, "Red")
+	}
+	n := 0
+	for _, suit := range trackerSuits {
+		n += t.Count(value, suit)
+	}
+	return n
+}
+
+// Wilds returns the number of wild heart level cards left unseen
+func (t *Tracker) Wilds() int {
+	return t.Count(t.level, "♥")
+}
+
+// Total returns the number of cards left unseen
+func (t *Tracker) Total() int {
+	n := 0
+	for _, count := range t.unseen {
+		n += count
+	}
+	return n
+}
+
+// Cards returns the cards left unseen, highest first in the level's order
+func (t *Tracker) Cards() []*deck.Card {
+	var cards []*deck.Card
+	for card, count := range t.unseen {
+		for i := 0; i < count; i++ {
+			cards = append(cards, deck.NewCard(card.Value, card.Suit))
+		}
+	}
+	NewLevelOrganizer(t.level).Sort(cards)
+	return cards
+}
+
+// String returns a table of the cards left unseen by rank, highest first, and suit,
+// with the jokers and the wild heart level cards
+func (t *Tracker) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-8s", "Rank")
+	for _, suit := range trackerSuits {
+		fmt.Fprintf(&b, "%3s", suit)
+	}
+	fmt.Fprintf(&b, "%6s\n", "Left")
+
+	fmt.Fprintf(&b, "%-8s%12s%6d\n", "RJ", "", t.Count("Joker", "Red"))
+	fmt.Fprintf(&b, "%-8s%12s%6d\n", "BJ", "", t.Count("Joker", "BW"))
+	values := []string{t.level}
+	for i := len(cardValues) - 1; i >= 0; i-- {
+		if cardValues[i] != t.level {
+			values = append(values, cardValues[i])
+		}
+	}
+	for _, value := range values {
+		label := value
+		if value == t.level {
+			label += " (lv)"
+		}
+		fmt.Fprintf(&b, "%-8s", label)
+		for _, suit := range trackerSuits {
+			fmt.Fprintf(&b, "%3d", t.Count(value, suit))
+		}
+		fmt.Fprintf(&b, "%6d\n", t.CountValue(value))
+	}
+
+	fmt.Fprintf(&b, "Wild %s♥ unseen: %d, jokers unseen: %d, cards unseen: %d", t.level, t.Wilds(), t.CountValue("Joker"), t.Total())
+	return b.String()
+}
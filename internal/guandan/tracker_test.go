@@ -0,0 +1,59 @@
+package guandan
+
+import (
+	"testing"
+
+	"github.com/genewoo/joker/internal/deck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrackerTestSuite struct {
+	suite.Suite
+}
+
+func TestTrackerSuite(t *testing.T) {
+	suite.Run(t, new(TrackerTestSuite))
+}
+
+func (suite *TrackerTestSuite) TestDeal() {
+	game := NewGame([4]int{1, 2, 3, 4}, [2]string{"7", "2"})
+	game.Seed(5)
+	game.DealCards()
+
+	tracker := game.Tracker(3)
+	assert.Equal(suite.T(), 81, tracker.Total(), "the cards of the other three players")
+	assert.Len(suite.T(), tracker.Cards(), 81)
+
+	held := make(map[deck.Card]int)
+	for _, card := range game.Hand(3).Cards {
+		held[*card]++
+	}
+	for card, count := range held {
+		assert.Equal(suite.T(), 2-count, tracker.Count(card.Value, card.Suit), "%s", card.String())
+	}
+	assert.Equal(suite.T(), 2-held[deck.Card{Value: "7", Suit: "♥"}], tracker.Wilds())
+
+	jokers := held[deck.Card{Value: "Joker", Suit: "Red"}] + held[deck.Card{Value: "Joker", Suit: "BW"}]
+	assert.Equal(suite.T(), 4-jokers, tracker.CountValue("Joker"))
+}
+
+func (suite *TrackerTestSuite) TestPlayedCards() {
+	game := newDealtGame(suite.T(), "5", "3s 3d 5h", "5s 5d RJ", "6h 6c", "7s 8d")
+	suite.Require().NoError(game.StartPlay(1))
+	playCards(suite.T(), game, 1, "3s 3d")
+	playCards(suite.T(), game, 2, "5s 5d")
+
+	tracker := game.Tracker(1)
+	assert.Equal(suite.T(), 108-5, tracker.Total(), "its hand and the played cards are seen")
+	assert.Equal(suite.T(), 1, tracker.Count("3", "♠"), "one of the two 3♠ is played")
+	assert.Equal(suite.T(), 1, tracker.Count("5", "♠"))
+	assert.Equal(suite.T(), 1, tracker.Wilds())
+	assert.Equal(suite.T(), 5, tracker.CountValue("5"))
+	assert.Equal(suite.T(), 2, tracker.Count("Joker", "Red"), "the other hands are not seen")
+
+	table := tracker.String()
+	assert.Contains(suite.T(), table, "5 (lv)    1  1  1  2     5\n")
+	assert.Contains(suite.T(), table, "RJ                       2\n")
+	assert.Contains(suite.T(), table, "Wild 5♥ unseen: 1, jokers unseen: 4, cards unseen: 103")
+}
@@ -0,0 +1,185 @@
+package guandan
+
+import (
+	"github.com/genewoo/joker/internal/deck"
+)
+
+// Tribute is a card paid by a player of the losing team of the last deal to a winner,
+// and the card the winner returned for it
+type Tribute struct {
+	Giver    int // Seat (1-4) of the player paying the tribute
+	Receiver int // Seat (1-4) of the player receiving it
+	Card     *deck.Card
+	Return   *deck.Card
+}
+
+// ReturnStrategy chooses the card a player returns for a tribute
+type ReturnStrategy interface {
+	// ChooseReturn returns one of the eligible cards of the receiver's hand, which are
+	// sorted from the highest; nil or a card that is not eligible returns the lowest one.
+	ChooseReturn(receiver int, eligible []*deck.Card) *deck.Card
+}
+
+// LowestReturn implements the ReturnStrategy of returning the lowest eligible card
+type LowestReturn struct{}
+
+func (LowestReturn) ChooseReturn(receiver int, eligible []*deck.Card) *deck.Card {
+	return eligible[len(eligible)-1]
+}
+
+// ChosenReturns implements ReturnStrategy with the cards the players chose to return, by seat
+type ChosenReturns map[int]*deck.Card
+
+func (c ChosenReturns) ChooseReturn(receiver int, eligible []*deck.Card) *deck.Card {
+	return c[receiver]
+}
+
+// SetReturnStrategy sets how the winners choose the cards they return for tributes;
+// LowestReturn is used by default.
+func (g *Game) SetReturnStrategy(strategy ReturnStrategy) {
+	g.returns = strategy
+}
+
+// SwapCards pays the tributes of the losing players to the winners of the last deal:
+//   - when the last two players are partners ("double down"), both pay their highest card;
+//     the higher tribute goes to the first player and the other to the second player, the
+//     third player's going first when they are equal
+//   - otherwise the last player pays their highest card to the first player
+//
+// A tribute is never a wild heart level card. Each receiver returns a card of 10 or lower,
+// as chosen by the return strategy, and the player who paid the highest tribute to the
+// first player becomes the dealer and leads the first trick.
+// The tributes are not paid if the paying players hold both red jokers between them.
+// Returns false if no tribute is paid, true otherwise.
+func (g *Game) SwapCards() bool {
+	g.tributes = nil
+	g.dealer = g.lastRanking[0]
+	combiner := NewCombiner(g.currentLevel)
+
+	givers := []int{g.lastRanking[3]}
+	receivers := []int{g.lastRanking[0]}
+	if partnerOf(g.lastRanking[2]) == g.lastRanking[3] {
+		givers = []int{g.lastRanking[2], g.lastRanking[3]}
+		receivers = []int{g.lastRanking[0], g.lastRanking[1]}
+	}
+
+	// Special rule: the losers resist paying tribute with both red jokers
+	redJokers := 0
+	for _, giver := range givers {
+		for _, card := range g.Hand(giver).Cards {
+			if card.Value == "Joker" && card.Suit == "Red" {
+				redJokers++
+			}
+		}
+	}
+	if redJokers == 2 {
+		return false
+	}
+
+	var tributes []Tribute
+	for _, giver := range givers {
+		if card := g.tributeCard(combiner, giver); card != nil {
+			tributes = append(tributes, Tribute{Giver: giver, Card: card})
+		}
+	}
+	if len(tributes) == 0 {
+		return false
+	}
+	if len(tributes) == 2 && combiner.Rank(tributes[1].Card) > combiner.Rank(tributes[0].Card) {
+		tributes[0], tributes[1] = tributes[1], tributes[0]
+	}
+
+	for i := range tributes {
+		t := &tributes[i]
+		t.Receiver = receivers[i]
+		giverHand, receiverHand := g.Hand(t.Giver), g.Hand(t.Receiver)
+
+		giverHand.RemoveCard(giverHand.IndexOf(t.Card))
+		receiverHand.AddCard(t.Card)
+
+		t.Return = g.returnCard(combiner, t.Receiver, t.Card)
+		receiverHand.RemoveCard(receiverHand.IndexOf(t.Return))
+		giverHand.AddCard(t.Return)
+	}
+
+	g.tributes = tributes
+	g.dealer = tributes[0].Giver
+	return true
+}
+
+// Tributes returns the tributes paid by the last SwapCards, the highest first
+func (g *Game) Tributes() []Tribute {
+	return g.tributes
+}
+
+// Dealer returns the seat (1-4) of the dealer, who leads the first trick of the deal:
+// the first player of the last deal, or the player who paid them the highest tribute.
+func (g *Game) Dealer() int {
+	return g.dealer
+}
+
+// tributeCard returns the giver's highest card that is not a wild card, or nil. The
+// strategy of the seat chooses among the cards of that rank, if it has one.
+func (g *Game) tributeCard(combiner *Combiner, giver int) *deck.Card {
+	var eligible []*deck.Card
+	for _, card := range g.Hand(giver).Cards {
+		if combiner.IsWild(card) {
+			continue
+		}
+		if len(eligible) > 0 && combiner.Rank(card) > combiner.Rank(eligible[0]) {
+			eligible = nil
+		}
+		if len(eligible) == 0 || combiner.Rank(card) == combiner.Rank(eligible[0]) {
+			eligible = append(eligible, card)
+		}
+	}
+	if len(eligible) == 0 {
+		return nil
+	}
+	if strategy := g.strategies[giver-1]; strategy != nil {
+		return chooseCard(eligible, strategy.ChooseTribute(giver, copyCards(eligible)), eligible[0])
+	}
+	return eligible[0]
+}
+
+// returnCard returns the card the receiver gives back for the tribute: a card ranked 10
+// or lower, or their lowest card other than the tribute if they have none. It is chosen by
+// the strategy of the seat, if it has one, or by the game's return strategy.
+func (g *Game) returnCard(combiner *Combiner, receiver int, tribute *deck.Card) *deck.Card {
+	var cards, eligible []*deck.Card
+	for _, card := range g.Hand(receiver).Cards {
+		if card == tribute {
+			continue
+		}
+		cards = append(cards, card)
+		if combiner.Rank(card) <= 10 {
+			eligible = append(eligible, card)
+		}
+	}
+	if len(eligible) == 0 {
+		eligible = cards
+	}
+	if len(eligible) == 0 {
+		return tribute
+	}
+	NewLevelOrganizer(g.currentLevel).Sort(eligible)
+
+	var strategy ReturnStrategy = LowestReturn{}
+	if g.strategies[receiver-1] != nil {
+		strategy = g.strategies[receiver-1]
+	} else if g.returns != nil {
+		strategy = g.returns
+	}
+	return chooseCard(eligible, strategy.ChooseReturn(receiver, copyCards(eligible)), eligible[len(eligible)-1])
+}
+
+// chooseCard returns the eligible card matching the choice by value and suit, or the
+// fallback card if there is none
+func chooseCard(eligible []*deck.Card, choice, fallback *deck.Card) *deck.Card {
+	for _, card := range eligible {
+		if choice != nil && card.Value == choice.Value && card.Suit == choice.Suit {
+			return card
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,119 @@
+package guandan
+
+import (
+	"testing"
+
+	"github.com/genewoo/joker/internal/deck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type TributeTestSuite struct {
+	suite.Suite
+}
+
+func TestTributeSuite(t *testing.T) {
+	suite.Run(t, new(TributeTestSuite))
+}
+
+// newTributeGame creates a game at level 2 with the hands of seats 1-4 in order,
+// after a deal that finished in lastRanking
+func (suite *TributeTestSuite) newTributeGame(lastRanking [4]int, hands ...string) *Game {
+	dealt := dealtHands(suite.T(), hands...)
+	game := NewGame(lastRanking, [2]string{"2", "2"})
+	for i, player := range game.players {
+		player.hand = dealt[i]
+	}
+	return game
+}
+
+func (suite *TributeTestSuite) TestTributeCard() {
+	tests := []struct {
+		name     string
+		giver    string
+		expected string
+	}{
+		{name: "Highest card", giver: "3s As Kd", expected: "A♠"},
+		{name: "Jokers first", giver: "As BJ", expected: "JokerBW"},
+		{name: "Level cards above A", giver: "As 2s", expected: "2♠"},
+		{name: "Never the wild card", giver: "As 2h", expected: "A♠"},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			game := suite.newTributeGame([4]int{1, 2, 3, 4}, "3c 4c", "3d", "4d", tt.giver)
+			suite.Require().True(game.SwapCards())
+			suite.Require().Len(game.Tributes(), 1)
+			tribute := game.Tributes()[0]
+			assert.Equal(suite.T(), 4, tribute.Giver)
+			assert.Equal(suite.T(), 1, tribute.Receiver)
+			assert.Equal(suite.T(), tt.expected, tribute.Card.String())
+			assert.Equal(suite.T(), 4, game.Dealer())
+		})
+	}
+}
+
+func (suite *TributeTestSuite) TestReturnCard() {
+	tests := []struct {
+		name     string
+		strategy ReturnStrategy
+		receiver string
+		expected string
+	}{
+		{name: "Lowest card by default", receiver: "Ks 9d 3c", expected: "3♣"},
+		{name: "Lowest card by level order", receiver: "Ks 9d 2c", expected: "9♦"},
+		{name: "Chosen card", strategy: ChosenReturns{1: deck.NewCard("9", "♦")}, receiver: "Ks 9d 3c", expected: "9♦"},
+		{name: "Chosen card above 10", strategy: ChosenReturns{1: deck.NewCard("K", "♠")}, receiver: "Ks 9d 3c", expected: "3♣"},
+		{name: "No card of 10 or lower", receiver: "Ks Qd", expected: "Q♦"},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			game := suite.newTributeGame([4]int{1, 2, 3, 4}, tt.receiver, "3d", "4d", "As 5c")
+			if tt.strategy != nil {
+				game.SetReturnStrategy(tt.strategy)
+			}
+			suite.Require().True(game.SwapCards())
+
+			tribute := game.Tributes()[0]
+			assert.Equal(suite.T(), tt.expected, tribute.Return.String())
+			assert.Equal(suite.T(), -1, game.Hand(1).IndexOf(tribute.Return))
+			assert.NotEqual(suite.T(), -1, game.Hand(1).IndexOf(tribute.Card))
+			assert.NotEqual(suite.T(), -1, game.Hand(4).IndexOf(tribute.Return))
+		})
+	}
+}
+
+func (suite *TributeTestSuite) TestDoubleDown() {
+	suite.Run("Higher tribute to the first player", func() {
+		game := suite.newTributeGame([4]int{2, 4, 1, 3}, "As 3c", "5s 4c", "Ks 3d", "6s 4d")
+		suite.Require().True(game.SwapCards())
+
+		tributes := game.Tributes()
+		suite.Require().Len(tributes, 2)
+		assert.Equal(suite.T(), 1, tributes[0].Giver)
+		assert.Equal(suite.T(), 2, tributes[0].Receiver)
+		assert.Equal(suite.T(), "A♠", tributes[0].Card.String())
+		assert.Equal(suite.T(), 3, tributes[1].Giver)
+		assert.Equal(suite.T(), 4, tributes[1].Receiver)
+		assert.Equal(suite.T(), "K♠", tributes[1].Card.String())
+		assert.Equal(suite.T(), 1, game.Dealer(), "The player paying the higher tribute deals")
+	})
+
+	suite.Run("Equal tributes", func() {
+		game := suite.newTributeGame([4]int{2, 4, 1, 3}, "As 3c", "5s 4c", "Ad 3d", "6s 4d")
+		suite.Require().True(game.SwapCards())
+
+		tributes := game.Tributes()
+		assert.Equal(suite.T(), 1, tributes[0].Giver, "The third player pays the first player")
+		assert.Equal(suite.T(), 2, tributes[0].Receiver)
+		assert.Equal(suite.T(), 1, game.Dealer())
+	})
+
+	suite.Run("Resisted with both red jokers", func() {
+		game := suite.newTributeGame([4]int{2, 4, 1, 3}, "RJ 3c", "5s 4c", "RJ 3d", "6s 4d")
+		assert.False(suite.T(), game.SwapCards())
+		assert.Empty(suite.T(), game.Tributes())
+		assert.Equal(suite.T(), 2, game.Dealer(), "The first player deals without tributes")
+	})
+}
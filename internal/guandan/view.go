@@ -0,0 +1,101 @@
+package guandan
+
+import (
+	"github.com/genewoo/joker/internal/deck"
+)
+
+// PlayerView is what the player at a seat may know of the deal: their own hand and
+// what was played in front of everyone, but never the hands of the other players.
+// Its cards are copies, so that changing them does not change the game.
+type PlayerView struct {
+	Seat       int
+	Hand       []*deck.Card // Cards of the player, highest first
+	Level      string       // Level the deal is played at
+	TeamLevels [2]string    // Levels of teams A and B
+	Dealer     int          // Seat that led the first trick
+	Tributes   []Tribute    // Tributes paid before the deal; the cards of the ones the seat neither gave nor received are nil
+	Plays      []Play       // Plays and passes of the deal so far, in order
+	Trick      *Play        // Play to beat, nil when the player to play leads a new trick
+	Turn       int          // Seat to play, 0 when no deal is being played
+	CardsLeft  [4]int       // Number of cards each seat holds
+}
+
+// View returns what the player at the seat (1-4) may know of the deal. Bots and clients
+// given only the view of their seat cannot see the other hands.
+func (g *Game) View(seat int) PlayerView {
+	view := PlayerView{
+		Seat:       seat,
+		Level:      g.currentLevel,
+		TeamLevels: [2]string{g.teams[0].level, g.teams[1].level},
+		Dealer:     g.dealer,
+		Turn:       g.turn,
+	}
+	if hand := g.Hand(seat); hand != nil {
+		view.Hand = copyCards(hand.Cards)
+		NewLevelOrganizer(g.currentLevel).Sort(view.Hand)
+	}
+	for s := 1; s <= 4; s++ {
+		if hand := g.Hand(s); hand != nil {
+			view.CardsLeft[s-1] = hand.Count()
+		}
+	}
+
+	for _, t := range g.tributes {
+		seen := Tribute{Giver: t.Giver, Receiver: t.Receiver}
+		if seat == t.Giver || seat == t.Receiver {
+			seen.Card, seen.Return = copyCard(t.Card), copyCard(t.Return)
+		}
+		view.Tributes = append(view.Tributes, seen)
+	}
+	for _, play := range g.plays {
+		view.Plays = append(view.Plays, copyPlay(play))
+	}
+	if g.trick != nil {
+		trick := copyPlay(*g.trick)
+		view.Trick = &trick
+	}
+	return view
+}
+
+// Leading reports whether the player to play leads a new trick, when they cannot pass
+func (v PlayerView) Leading() bool {
+	return v.Trick == nil
+}
+
+// PlayedCards returns every card played in the deal so far, in the order they were played
+func (v PlayerView) PlayedCards() []*deck.Card {
+	var cards []*deck.Card
+	for _, play := range v.Plays {
+		cards = append(cards, play.Cards...)
+	}
+	return cards
+}
+
+// copyPlay returns a copy of the play and of its cards
+func copyPlay(p Play) Play {
+	p.Cards = copyCards(p.Cards)
+	substitutions := p.Combination.Substitutions
+	p.Combination.Substitutions = nil
+	for _, s := range substitutions {
+		p.Combination.Substitutions = append(p.Combination.Substitutions,
+			Substitution{Wild: copyCard(s.Wild), As: copyCard(s.As)})
+	}
+	return p
+}
+
+// copyCards returns copies of the cards
+func copyCards(cards []*deck.Card) []*deck.Card {
+	copies := make([]*deck.Card, len(cards))
+	for i, card := range cards {
+		copies[i] = copyCard(card)
+	}
+	return copies
+}
+
+// copyCard returns a copy of the card, or nil
+func copyCard(card *deck.Card) *deck.Card {
+	if card == nil {
+		return nil
+	}
+	return deck.NewCard(card.Value, card.Suit)
+}
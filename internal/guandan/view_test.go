@@ -0,0 +1,86 @@
+package guandan
+
+import (
+	"testing"
+
+	"github.com/genewoo/joker/internal/deck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ViewTestSuite struct {
+	suite.Suite
+}
+
+func TestViewSuite(t *testing.T) {
+	suite.Run(t, new(ViewTestSuite))
+}
+
+func (suite *ViewTestSuite) TestDeal() {
+	game := NewGame([4]int{1, 2, 3, 4}, [2]string{"5", "3"})
+	game.Seed(1)
+	game.DealCards()
+	suite.Require().True(game.SwapCards())
+
+	view := game.View(2)
+	assert.Equal(suite.T(), 2, view.Seat)
+	assert.Equal(suite.T(), "5", view.Level)
+	assert.Equal(suite.T(), [2]string{"5", "3"}, view.TeamLevels)
+	assert.Equal(suite.T(), 4, view.Dealer)
+	assert.Equal(suite.T(), [4]int{27, 27, 27, 27}, view.CardsLeft)
+	assert.Equal(suite.T(), 0, view.Turn)
+	assert.Empty(suite.T(), view.Plays)
+
+	hand := deck.NewHand(game.Hand(2).Cards...)
+	hand.SetOrganizer(NewLevelOrganizer("5"))
+	hand.Sort()
+	assert.Equal(suite.T(), hand.Cards, view.Hand, "sees its own hand, highest first")
+
+	suite.Require().Len(view.Tributes, 1)
+	assert.Equal(suite.T(), Tribute{Giver: 4, Receiver: 1}, view.Tributes[0], "does not see the tribute of others")
+
+	tribute := game.Tributes()[0]
+	for _, seat := range []int{1, 4} {
+		seen := game.View(seat).Tributes[0]
+		assert.Equal(suite.T(), tribute.Card.String(), seen.Card.String(), "seat %d", seat)
+		assert.Equal(suite.T(), tribute.Return.String(), seen.Return.String(), "seat %d", seat)
+	}
+}
+
+func (suite *ViewTestSuite) TestPlay() {
+	game := newDealtGame(suite.T(), "2", "3s 3d 9c", "5s 5d Kd", "6h 6c Qs", "7s 8d")
+	suite.Require().NoError(game.StartPlay(1))
+
+	view := game.View(3)
+	assert.True(suite.T(), view.Leading())
+	assert.Equal(suite.T(), 1, view.Turn)
+
+	playCards(suite.T(), game, 1, "3s 3d")
+	playCards(suite.T(), game, 2, "5s 5d")
+
+	view = game.View(3)
+	assert.False(suite.T(), view.Leading())
+	assert.Equal(suite.T(), 3, view.Turn)
+	assert.Equal(suite.T(), 2, view.Trick.Seat)
+	assert.Equal(suite.T(), Pair, view.Trick.Combination.Type)
+	assert.Equal(suite.T(), [4]int{1, 1, 3, 2}, view.CardsLeft)
+	assert.Len(suite.T(), view.Plays, 2)
+	assert.Equal(suite.T(), "5♠,5♦,3♠,3♦", deck.NewHand(view.PlayedCards()...).String())
+	assert.Equal(suite.T(), "3♠", view.PlayedCards()[0].String(), "in the order they were played")
+	assert.Equal(suite.T(), "Q♠,6♥,6♣", deck.NewHand(view.Hand...).String())
+}
+
+func (suite *ViewTestSuite) TestCopies() {
+	game := NewGame([4]int{1, 2, 3, 4}, [2]string{"2", "2"})
+	game.Seed(3)
+	game.DealCards()
+	suite.Require().NoError(game.StartPlay(1))
+
+	before := game.Hand(1).String()
+	view := game.View(1)
+	view.Hand[0].Value = "X"
+	view.Hand[0] = nil
+	assert.Equal(suite.T(), before, game.Hand(1).String(), "changing the view does not change the game")
+
+	assert.Empty(suite.T(), NewGame([4]int{1, 2, 3, 4}, [2]string{"2", "2"}).View(1).Hand, "nothing is dealt")
+}
@@ -0,0 +1,536 @@
+package holdem
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// BettingStructure represents the limit on the size of bets and raises
+type BettingStructure int
+
+const (
+	// NoLimit allows any bet or raise up to the player's whole stack
+	NoLimit BettingStructure = iota
+	// PotLimit allows bets and raises up to the size of the pot
+	PotLimit
+	// FixedLimit allows bets and raises of exactly one small bet (pre-flop and flop)
+	// or one big bet (turn and river), up to a capped number per street
+	FixedLimit
+)
+
+// String returns the string representation of the BettingStructure
+func (b BettingStructure) String() string {
+	switch b {
+	case NoLimit:
+		return "no-limit"
+	case PotLimit:
+		return "pot-limit"
+	case FixedLimit:
+		return "fixed-limit"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseBettingStructure converts a string, as returned by BettingStructure.String, to a BettingStructure
+func ParseBettingStructure(s string) (BettingStructure, error) {
+	for _, b := range []BettingStructure{NoLimit, PotLimit, FixedLimit} {
+		if strings.EqualFold(s, b.String()) {
+			return b, nil
+		}
+	}
+	return NoLimit, fmt.Errorf("invalid betting structure '%s'. Must be one of: no-limit, pot-limit, fixed-limit", s)
+}
+
+// DefaultRaiseCap is the number of bets and raises allowed per street in fixed-limit play
+// when BettingConfig.RaiseCap is not set.
+const DefaultRaiseCap = 4
+
+// BettingConfig contains the forced bets and betting structure of a game.
+// In fixed-limit play the big blind is the small bet and twice the big blind the big bet.
+type BettingConfig struct {
+	Structure  BettingStructure
+	SmallBlind int
+	BigBlind   int
+	Ante       int
+	RaiseCap   int // Bets and raises allowed per street in fixed-limit play, including the big blind pre-flop
+}
+
+// Street represents a betting round of a hand
+type Street int
+
+const (
+	// Preflop is the betting round after the hole cards are dealt
+	Preflop Street = iota
+	// Flop is the betting round after the first three community cards
+	Flop
+	// Turn is the betting round after the fourth community card
+	Turn
+	// River is the betting round after the fifth community card
+	River
+	// Showdown is reached when the betting is over, either after the river or
+	// because all but one player folded
+	Showdown
+)
+
+// String returns the string representation of the Street
+func (s Street) String() string {
+	switch s {
+	case Preflop:
+		return "preflop"
+	case Flop:
+		return "flop"
+	case Turn:
+		return "turn"
+	case River:
+		return "river"
+	case Showdown:
+		return "showdown"
+	default:
+		return "unknown"
+	}
+}
+
+// ActionType represents the kind of a betting action
+type ActionType int
+
+const (
+	// Fold gives up the hand
+	Fold ActionType = iota
+	// Check passes the action when there is nothing to call
+	Check
+	// Call matches the current bet, or puts the player all-in if they cannot
+	Call
+	// Bet opens the betting on a street
+	Bet
+	// Raise increases the current bet
+	Raise
+	// AllIn puts the player's whole stack in, as a call, bet or raise
+	AllIn
+)
+
+// String returns the string representation of the ActionType
+func (a ActionType) String() string {
+	switch a {
+	case Fold:
+		return "fold"
+	case Check:
+		return "check"
+	case Call:
+		return "call"
+	case Bet:
+		return "bet"
+	case Raise:
+		return "raise"
+	case AllIn:
+		return "all-in"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseActionType converts a string, as returned by ActionType.String, to an ActionType
+func ParseActionType(s string) (ActionType, error) {
+	for _, a := range []ActionType{Fold, Check, Call, Bet, Raise, AllIn} {
+		if strings.EqualFold(s, a.String()) {
+			return a, nil
+		}
+	}
+	return Fold, fmt.Errorf("invalid action type '%s'. Must be one of: fold, check, call, bet, raise, all-in", s)
+}
+
+// Action is a betting action taken by a player.
+// Amount is the player's total bet on the street after the action: the size of a
+// bet, or the amount raised to. It is only read for Bet and Raise, and is filled
+// in by Game.Act for the other action types.
+type Action struct {
+	Player int
+	Type   ActionType
+	Amount int
+}
+
+// Errors returned, wrapped in an ActionError, for illegal betting actions
+var (
+	ErrNoBetting          = errors.New("betting is not configured")
+	ErrBettingClosed      = errors.New("no player is to act")
+	ErrOutOfTurn          = errors.New("player is not the next to act")
+	ErrCannotCheck        = errors.New("cannot check facing a bet")
+	ErrNothingToCall      = errors.New("there is no bet to call")
+	ErrCannotBet          = errors.New("cannot bet facing a bet, raise instead")
+	ErrCannotRaise        = errors.New("there is no bet to raise, bet instead")
+	ErrBelowMinimum       = errors.New("amount is below the minimum bet or raise")
+	ErrAboveMaximum       = errors.New("amount is above the maximum bet or raise")
+	ErrInsufficientChips  = errors.New("not enough chips")
+	ErrRaiseCapReached    = errors.New("the number of raises on this street is capped")
+	ErrActionNotReopened  = errors.New("an all-in for less than a full raise does not reopen the betting")
+	ErrUnknownAction      = errors.New("unknown action")
+	ErrWrongStreet        = errors.New("cards dealt out of order")
+	ErrBettingNotComplete = errors.New("betting round is not complete")
+)
+
+// ActionError is returned by Game.Act when an action is not allowed.
+// Err is one of the Err* sentinel errors and can be matched with errors.Is.
+type ActionError struct {
+	Action Action
+	Err    error
+}
+
+// Error returns a description of the rejected action and the reason
+func (e *ActionError) Error() string {
+	if e.Action.Type == Bet || e.Action.Type == Raise {
+		return fmt.Sprintf("player %d cannot %s %d: %v", e.Action.Player, e.Action.Type, e.Action.Amount, e.Err)
+	}
+	return fmt.Sprintf("player %d cannot %s: %v", e.Action.Player, e.Action.Type, e.Err)
+}
+
+// Unwrap returns the sentinel error
+func (e *ActionError) Unwrap() error {
+	return e.Err
+}
+
+// SetBetting enables betting with the given configuration. The forced bets are
+// posted by StartHand, the betting rounds are played with Act, and DealFlop and
+// DealTurnOrRiver only deal once the current round is complete.
+func (g *Game) SetBetting(config BettingConfig) error {
+	if config.BigBlind <= 0 {
+		return fmt.Errorf("big blind must be positive, got %d", config.BigBlind)
+	}
+	if config.SmallBlind < 0 || config.SmallBlind > config.BigBlind {
+		return fmt.Errorf("small blind must be between 0 and the big blind, got %d", config.SmallBlind)
+	}
+	if config.Ante < 0 {
+		return fmt.Errorf("ante cannot be negative, got %d", config.Ante)
+	}
+	if config.RaiseCap < 0 {
+		return fmt.Errorf("raise cap cannot be negative, got %d", config.RaiseCap)
+	}
+	if config.RaiseCap == 0 {
+		config.RaiseCap = DefaultRaiseCap
+	}
+	g.betting = &config
+	return nil
+}
+
+// Street returns the current betting round
+func (g *Game) Street() Street {
+	return g.street
+}
+
+// ToAct returns the index of the player whose turn it is, or -1 if no player is to act.
+func (g *Game) ToAct() int {
+	return g.toAct
+}
+
+// Pot returns the chips in the pot, including the bets of the current street.
+func (g *Game) Pot() int {
+	total := g.pot
+	for _, p := range g.Players {
+		total += p.Bet
+	}
+	return total
+}
+
+// CurrentBet returns the highest bet on the current street, which players must call to stay in.
+func (g *Game) CurrentBet() int {
+	return g.currentBet
+}
+
+// RaiseLimits returns the smallest and largest amounts the player to act may bet or
+// raise to. The minimum can exceed the maximum when the player can only go all-in for less.
+func (g *Game) RaiseLimits() (int, int) {
+	if g.toAct < 0 {
+		return 0, 0
+	}
+	p := &g.Players[g.toAct]
+	stack := p.Bet + p.Chips
+	minTo := g.currentBet + g.lastRaise
+	maxTo := stack
+
+	switch g.betting.Structure {
+	case PotLimit:
+		potAfterCall := g.Pot() + g.currentBet - p.Bet
+		maxTo = min(stack, g.currentBet+potAfterCall)
+	case FixedLimit:
+		maxTo = min(stack, minTo)
+	}
+	return minTo, maxTo
+}
+
+// startBetting resets the players' betting state, posts the antes and blinds,
+// and opens the pre-flop betting round.
+func (g *Game) startBetting() error {
+	if len(g.Players) < 2 {
+		return fmt.Errorf("betting requires at least 2 players, got %d", len(g.Players))
+	}
+	if g.Button < 0 || g.Button >= len(g.Players) {
+		return fmt.Errorf("button %d is not a seat at the table", g.Button)
+	}
+	for i, p := range g.Players {
+		if p.Chips <= 0 {
+			return fmt.Errorf("player %d has no chips", i)
+		}
+	}
+
+	g.pot = 0
+	for i := range g.Players {
+		p := &g.Players[i]
+		p.Bet, p.Contributed, p.Folded, p.AllIn = 0, 0, false, false
+		p.acted, p.actedAt = false, 0
+
+		ante := min(g.betting.Ante, p.Chips)
+		p.Chips -= ante
+		p.Contributed += ante
+		g.pot += ante
+		p.AllIn = p.Chips == 0
+	}
+
+	// Heads-up the button posts the small blind
+	smallBlind := g.nextSeat(g.Button)
+	if len(g.Players) == 2 {
+		smallBlind = g.Button
+	}
+	bigBlind := g.nextSeat(smallBlind)
+	g.postBlind(smallBlind, g.betting.SmallBlind)
+	g.postBlind(bigBlind, g.betting.BigBlind)
+
+	g.street = Preflop
+	g.currentBet = g.betting.BigBlind
+	g.lastRaise = g.betting.BigBlind
+	g.raises = 1
+	g.advance(bigBlind)
+	return nil
+}
+
+func (g *Game) postBlind(seat, amount int) {
+	p := &g.Players[seat]
+	if p.AllIn {
+		return
+	}
+	g.putIn(p, min(amount, p.Chips))
+}
+
+// putIn moves chips from the player's stack to their bet on the street
+func (g *Game) putIn(p *Player, amount int) {
+	p.Chips -= amount
+	p.Bet += amount
+	p.Contributed += amount
+	p.AllIn = p.Chips == 0
+}
+
+func (g *Game) nextSeat(seat int) int {
+	return (seat + 1) % len(g.Players)
+}
+
+// Act applies a betting action by the player to act and passes the action on.
+// Returns an *ActionError if the action is not allowed; the game is then unchanged.
+func (g *Game) Act(action Action) error {
+	street := g.street
+	if err := g.act(&action); err != nil {
+		return &ActionError{Action: action, Err: err}
+	}
+	g.recordAction(street, action)
+	return nil
+}
+
+func (g *Game) act(action *Action) error {
+	if g.betting == nil {
+		return ErrNoBetting
+	}
+	if g.toAct < 0 {
+		return ErrBettingClosed
+	}
+	if action.Player != g.toAct {
+		return ErrOutOfTurn
+	}
+
+	p := &g.Players[action.Player]
+	toCall := g.currentBet - p.Bet
+	switch action.Type {
+	case Fold:
+		p.Folded = true
+	case Check:
+		if toCall > 0 {
+			return ErrCannotCheck
+		}
+	case Call:
+		if toCall == 0 {
+			return ErrNothingToCall
+		}
+		g.putIn(p, min(toCall, p.Chips))
+	case Bet:
+		if g.currentBet > 0 {
+			return ErrCannotBet
+		}
+		if err := g.raiseTo(p, action.Amount); err != nil {
+			return err
+		}
+	case Raise:
+		if g.currentBet == 0 {
+			return ErrCannotRaise
+		}
+		if err := g.raiseTo(p, action.Amount); err != nil {
+			return err
+		}
+	case AllIn:
+		if p.Bet+p.Chips <= g.currentBet {
+			g.putIn(p, p.Chips)
+		} else if err := g.raiseTo(p, p.Bet+p.Chips); err != nil {
+			return err
+		}
+	default:
+		return ErrUnknownAction
+	}
+
+	action.Amount = p.Bet
+	p.acted = true
+	p.actedAt = g.currentBet
+	g.advance(action.Player)
+	return nil
+}
+
+// raiseTo validates and applies a bet or raise to the given total for the street.
+func (g *Game) raiseTo(p *Player, amount int) error {
+	stack := p.Bet + p.Chips
+	if amount > stack {
+		return ErrInsufficientChips
+	}
+	if p.acted && g.currentBet-p.actedAt < g.lastRaise {
+		return ErrActionNotReopened
+	}
+	if g.betting.Structure == FixedLimit && g.raises >= g.betting.RaiseCap {
+		return ErrRaiseCapReached
+	}
+
+	// A player may always go all-in, even for less than a minimum raise
+	minTo, maxTo := g.RaiseLimits()
+	if amount <= g.currentBet || (amount < minTo && amount < stack) {
+		return ErrBelowMinimum
+	}
+	if amount > maxTo {
+		return ErrAboveMaximum
+	}
+
+	if increase := amount - g.currentBet; increase >= g.lastRaise {
+		g.lastRaise = increase
+		g.raises++
+	}
+	g.currentBet = amount
+	g.putIn(p, amount-p.Bet)
+	return nil
+}
+
+// advance passes the action to the next player after the seat who needs to act,
+// closing the betting round when nobody does.
+func (g *Game) advance(seat int) {
+	if g.activePlayers() == 1 {
+		g.toAct = -1
+		g.endRound()
+		g.street = Showdown
+		return
+	}
+
+	for i := 1; i <= len(g.Players); i++ {
+		next := (seat + i) % len(g.Players)
+		if g.needsAction(next) {
+			g.toAct = next
+			return
+		}
+	}
+
+	g.toAct = -1
+	g.endRound()
+	if g.street == River {
+		g.street = Showdown
+	}
+}
+
+// needsAction reports whether the player still has to act in the betting round.
+func (g *Game) needsAction(seat int) bool {
+	p := g.Players[seat]
+	if p.Folded || p.AllIn {
+		return false
+	}
+	if p.Bet < g.currentBet {
+		return true
+	}
+	if p.acted {
+		return false
+	}
+
+	// A player who has matched the bet has nobody left to bet against
+	// when everyone else is all-in or folded
+	for i, other := range g.Players {
+		if i != seat && !other.Folded && !other.AllIn {
+			return true
+		}
+	}
+	return false
+}
+
+// activePlayers returns the number of players who have not folded
+func (g *Game) activePlayers() int {
+	count := 0
+	for _, p := range g.Players {
+		if !p.Folded {
+			count++
+		}
+	}
+	return count
+}
+
+// endRound returns any uncalled bet and gathers the street's bets into the pot.
+func (g *Game) endRound() {
+	top, second := -1, 0
+	for i, p := range g.Players {
+		if top < 0 || p.Bet > g.Players[top].Bet {
+			if top >= 0 {
+				second = g.Players[top].Bet
+			}
+			top = i
+		} else if p.Bet > second {
+			second = p.Bet
+		}
+	}
+	if uncalled := g.Players[top].Bet - second; uncalled > 0 {
+		g.Players[top].Bet -= uncalled
+		g.Players[top].Contributed -= uncalled
+		g.Players[top].Chips += uncalled
+		g.Players[top].AllIn = false
+	}
+
+	for i := range g.Players {
+		p := &g.Players[i]
+		g.pot += p.Bet
+		p.Bet = 0
+		p.acted, p.actedAt = false, 0
+	}
+	g.currentBet = 0
+}
+
+// startStreet opens the betting round of a street after its cards are dealt.
+// Post-flop the first player left of the button acts first.
+func (g *Game) startStreet(street Street) {
+	g.street = street
+	g.currentBet = 0
+	g.lastRaise = g.betting.BigBlind
+	if g.betting.Structure == FixedLimit && street >= Turn {
+		g.lastRaise = 2 * g.betting.BigBlind
+	}
+	g.raises = 0
+	g.advance(g.Button)
+}
+
+// checkStreet returns an error unless the betting allows the cards of the street to be dealt.
+func (g *Game) checkStreet(street Street) error {
+	if g.betting == nil {
+		return nil
+	}
+	if g.street != street-1 {
+		return fmt.Errorf("%w: cannot deal the %s during the %s", ErrWrongStreet, street, g.street)
+	}
+	if g.toAct >= 0 {
+		return fmt.Errorf("%w: player %d is still to act", ErrBettingNotComplete, g.toAct)
+	}
+	return nil
+}
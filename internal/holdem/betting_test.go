@@ -0,0 +1,306 @@
+package holdem
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newBettingGame creates a game with the given stacks, configures betting and starts a hand.
+func newBettingGame(t *testing.T, config BettingConfig, button int, stacks ...int) *Game {
+	t.Helper()
+	game := NewGame(Texas, len(stacks))
+	for i, chips := range stacks {
+		game.Players[i].Chips = chips
+	}
+	game.Button = button
+	assert.NoError(t, game.SetBetting(config))
+	assert.NoError(t, game.StartHand())
+	return game
+}
+
+func act(t *testing.T, game *Game, actions ...Action) {
+	t.Helper()
+	for _, action := range actions {
+		assert.NoError(t, game.Act(action), "action %+v", action)
+	}
+}
+
+var noLimit = BettingConfig{Structure: NoLimit, SmallBlind: 1, BigBlind: 2}
+
+func TestSetBetting(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      BettingConfig
+		expectError bool
+	}{
+		{name: "Valid", config: BettingConfig{SmallBlind: 1, BigBlind: 2, Ante: 1}},
+		{name: "No small blind", config: BettingConfig{BigBlind: 2}},
+		{name: "No big blind", config: BettingConfig{SmallBlind: 1}, expectError: true},
+		{name: "Small blind above big blind", config: BettingConfig{SmallBlind: 3, BigBlind: 2}, expectError: true},
+		{name: "Negative ante", config: BettingConfig{SmallBlind: 1, BigBlind: 2, Ante: -1}, expectError: true},
+		{name: "Negative raise cap", config: BettingConfig{BigBlind: 2, RaiseCap: -1}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewGame(Texas, 2)
+			err := game.SetBetting(tt.config)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStartHandPostsForcedBets(t *testing.T) {
+	t.Run("Blinds and antes", func(t *testing.T) {
+		game := newBettingGame(t, BettingConfig{SmallBlind: 1, BigBlind: 2, Ante: 1}, 0, 100, 100, 100, 100)
+		assert.Equal(t, []int{99, 98, 97, 99}, []int{game.Players[0].Chips, game.Players[1].Chips, game.Players[2].Chips, game.Players[3].Chips})
+		assert.Equal(t, 1, game.Players[1].Bet)
+		assert.Equal(t, 2, game.Players[2].Bet)
+		assert.Equal(t, 7, game.Pot())
+		assert.Equal(t, 2, game.CurrentBet())
+		assert.Equal(t, Preflop, game.Street())
+		assert.Equal(t, 3, game.ToAct()) // under the gun
+	})
+
+	t.Run("Heads-up button posts the small blind", func(t *testing.T) {
+		game := newBettingGame(t, noLimit, 1, 100, 100)
+		assert.Equal(t, 1, game.Players[1].Bet)
+		assert.Equal(t, 2, game.Players[0].Bet)
+		assert.Equal(t, 1, game.ToAct())
+	})
+
+	t.Run("Short stack posts all-in", func(t *testing.T) {
+		game := newBettingGame(t, noLimit, 0, 100, 100, 1)
+		assert.True(t, game.Players[2].AllIn)
+		assert.Equal(t, 1, game.Players[2].Bet)
+		assert.Equal(t, 2, game.CurrentBet())
+	})
+
+	t.Run("Player without chips", func(t *testing.T) {
+		game := NewGame(Texas, 2)
+		game.Players[0].Chips = 100
+		assert.NoError(t, game.SetBetting(noLimit))
+		assert.Error(t, game.StartHand())
+	})
+}
+
+func TestActErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   BettingConfig
+		setup    []Action
+		action   Action
+		expected error
+	}{
+		{name: "Out of turn", config: noLimit, action: Action{Player: 0, Type: Call}, expected: ErrOutOfTurn},
+		{name: "Check facing a bet", config: noLimit, action: Action{Player: 2, Type: Check}, expected: ErrCannotCheck},
+		{name: "Bet facing a bet", config: noLimit, action: Action{Player: 2, Type: Bet, Amount: 6}, expected: ErrCannotBet},
+		{name: "Raise below minimum", config: noLimit, action: Action{Player: 2, Type: Raise, Amount: 3}, expected: ErrBelowMinimum},
+		{name: "Raise above stack", config: noLimit, action: Action{Player: 2, Type: Raise, Amount: 101}, expected: ErrInsufficientChips},
+		{name: "Unknown action", config: noLimit, action: Action{Player: 2, Type: ActionType(42)}, expected: ErrUnknownAction},
+		{
+			name:     "Call with nothing to call",
+			config:   noLimit,
+			setup:    []Action{{Player: 2, Type: Call}, {Player: 0, Type: Call}},
+			action:   Action{Player: 1, Type: Call},
+			expected: ErrNothingToCall,
+		},
+		{
+			name:     "Re-raise below the last raise",
+			config:   noLimit,
+			setup:    []Action{{Player: 2, Type: Raise, Amount: 10}},
+			action:   Action{Player: 0, Type: Raise, Amount: 17},
+			expected: ErrBelowMinimum,
+		},
+		{
+			name:     "Pot-limit raise above the pot",
+			config:   BettingConfig{Structure: PotLimit, SmallBlind: 1, BigBlind: 2},
+			action:   Action{Player: 2, Type: Raise, Amount: 8},
+			expected: ErrAboveMaximum,
+		},
+		{
+			name:     "Fixed-limit raise of the wrong size",
+			config:   BettingConfig{Structure: FixedLimit, SmallBlind: 1, BigBlind: 2},
+			action:   Action{Player: 2, Type: Raise, Amount: 6},
+			expected: ErrAboveMaximum,
+		},
+		{
+			name:   "Fixed-limit raise cap",
+			config: BettingConfig{Structure: FixedLimit, SmallBlind: 1, BigBlind: 2},
+			setup: []Action{
+				{Player: 2, Type: Raise, Amount: 4},
+				{Player: 0, Type: Raise, Amount: 6},
+				{Player: 1, Type: Raise, Amount: 8},
+			},
+			action:   Action{Player: 2, Type: Raise, Amount: 10},
+			expected: ErrRaiseCapReached,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newBettingGame(t, tt.config, 2, 100, 100, 100)
+			act(t, game, tt.setup...)
+			pot, toAct := game.Pot(), game.ToAct()
+
+			err := game.Act(tt.action)
+			assert.ErrorIs(t, err, tt.expected)
+			var actionErr *ActionError
+			assert.True(t, errors.As(err, &actionErr))
+			assert.Equal(t, tt.action.Player, actionErr.Action.Player)
+
+			// A rejected action leaves the game unchanged
+			assert.Equal(t, pot, game.Pot())
+			assert.Equal(t, toAct, game.ToAct())
+		})
+	}
+
+	t.Run("Without betting", func(t *testing.T) {
+		game := NewGame(Texas, 2)
+		assert.ErrorIs(t, game.Act(Action{Type: Check}), ErrNoBetting)
+	})
+}
+
+func TestPotLimitMaximum(t *testing.T) {
+	// Blinds 1/2: calling 2 makes the pot 5, so the pot-sized raise is to 7
+	game := newBettingGame(t, BettingConfig{Structure: PotLimit, SmallBlind: 1, BigBlind: 2}, 2, 100, 100, 100)
+	minTo, maxTo := game.RaiseLimits()
+	assert.Equal(t, 4, minTo)
+	assert.Equal(t, 7, maxTo)
+	act(t, game, Action{Player: 2, Type: Raise, Amount: 7})
+
+	// The small blind calls 6 making the pot 16, so can raise to 23
+	_, maxTo = game.RaiseLimits()
+	assert.Equal(t, 23, maxTo)
+}
+
+func TestShortAllInDoesNotReopenBetting(t *testing.T) {
+	game := newBettingGame(t, noLimit, 2, 100, 100, 15)
+	act(t, game,
+		Action{Player: 2, Type: Call},
+		Action{Player: 0, Type: Call},
+		Action{Player: 1, Type: Check},
+	)
+	assert.NoError(t, game.DealFlop())
+
+	act(t, game,
+		Action{Player: 0, Type: Bet, Amount: 10},
+		Action{Player: 1, Type: Call},
+		Action{Player: 2, Type: AllIn}, // 13 is less than a full raise to 20
+	)
+	assert.True(t, game.Players[2].AllIn)
+	assert.Equal(t, 13, game.CurrentBet())
+
+	// Player 0 already acted and may only call or fold
+	assert.ErrorIs(t, game.Act(Action{Player: 0, Type: Raise, Amount: 30}), ErrActionNotReopened)
+	act(t, game, Action{Player: 0, Type: Call}, Action{Player: 1, Type: Call})
+	assert.Equal(t, -1, game.ToAct())
+	assert.Equal(t, 45, game.Pot())
+}
+
+func TestFullHand(t *testing.T) {
+	game := newBettingGame(t, noLimit, 0, 100, 100, 100)
+
+	// Dealing is blocked until the round is complete
+	assert.ErrorIs(t, game.DealFlop(), ErrBettingNotComplete)
+	assert.ErrorIs(t, game.DealTurnOrRiver(), ErrWrongStreet)
+
+	// Pre-flop: the big blind gets the option after the limpers
+	act(t, game,
+		Action{Player: 0, Type: Call},
+		Action{Player: 1, Type: Call},
+	)
+	assert.Equal(t, 2, game.ToAct())
+	act(t, game, Action{Player: 2, Type: Raise, Amount: 8})
+	act(t, game,
+		Action{Player: 0, Type: Fold},
+		Action{Player: 1, Type: Call},
+	)
+	assert.Equal(t, -1, game.ToAct())
+	assert.Equal(t, 18, game.Pot())
+
+	// Flop: the first player left of the button acts first
+	assert.NoError(t, game.DealFlop())
+	assert.Equal(t, Flop, game.Street())
+	assert.Equal(t, 1, game.ToAct())
+	act(t, game,
+		Action{Player: 1, Type: Check},
+		Action{Player: 2, Type: Bet, Amount: 10},
+		Action{Player: 1, Type: Call},
+	)
+
+	// Turn: checked through
+	assert.NoError(t, game.DealTurnOrRiver())
+	assert.Equal(t, Turn, game.Street())
+	act(t, game,
+		Action{Player: 1, Type: Check},
+		Action{Player: 2, Type: Check},
+	)
+
+	// River: a bet is folded to and the uncalled bet is returned
+	assert.NoError(t, game.DealTurnOrRiver())
+	assert.Equal(t, River, game.Street())
+	act(t, game,
+		Action{Player: 1, Type: Bet, Amount: 20},
+		Action{Player: 2, Type: Fold},
+	)
+	assert.Equal(t, Showdown, game.Street())
+	assert.Equal(t, -1, game.ToAct())
+	assert.Equal(t, 38, game.Pot())
+	assert.Equal(t, 82, game.Players[1].Chips)
+	assert.ErrorIs(t, game.Act(Action{Player: 1, Type: Check}), ErrBettingClosed)
+}
+
+func TestAllInRunsOutTheBoard(t *testing.T) {
+	game := newBettingGame(t, noLimit, 0, 50, 100)
+	act(t, game,
+		Action{Player: 0, Type: AllIn},
+		Action{Player: 1, Type: Call},
+	)
+	assert.True(t, game.Players[0].AllIn)
+	assert.Equal(t, 50, game.Players[1].Chips)
+	assert.Equal(t, 100, game.Pot())
+
+	// No more betting: the board is dealt out to the showdown
+	assert.NoError(t, game.DealFlop())
+	assert.Equal(t, -1, game.ToAct())
+	assert.NoError(t, game.DealTurnOrRiver())
+	assert.NoError(t, game.DealTurnOrRiver())
+	assert.Equal(t, Showdown, game.Street())
+}
+
+func TestFixedLimitBetSizes(t *testing.T) {
+	game := newBettingGame(t, BettingConfig{Structure: FixedLimit, SmallBlind: 1, BigBlind: 2}, 0, 100, 100)
+	act(t, game,
+		Action{Player: 0, Type: Call},
+		Action{Player: 1, Type: Check},
+	)
+	assert.NoError(t, game.DealFlop())
+	minTo, maxTo := game.RaiseLimits()
+	assert.Equal(t, []int{2, 2}, []int{minTo, maxTo})
+	act(t, game,
+		Action{Player: 1, Type: Check},
+		Action{Player: 0, Type: Check},
+	)
+
+	// The big bet applies on the turn
+	assert.NoError(t, game.DealTurnOrRiver())
+	minTo, maxTo = game.RaiseLimits()
+	assert.Equal(t, []int{4, 4}, []int{minTo, maxTo})
+	act(t, game, Action{Player: 1, Type: Bet, Amount: 4})
+	minTo, maxTo = game.RaiseLimits()
+	assert.Equal(t, []int{8, 8}, []int{minTo, maxTo})
+}
+
+func TestAddToPot(t *testing.T) {
+	game := NewGame(Texas, 2)
+	game.AddToPot(10)
+	game.AddToPot(5)
+	assert.Equal(t, 15, game.Pot())
+}
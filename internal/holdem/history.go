@@ -0,0 +1,292 @@
+package holdem
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/genewoo/joker/internal/deck"
+)
+
+// HandHistory is a structured log of a hand, recorded by a Game with recording enabled.
+// Cards are written in ASCII notation such as "As" or "Th". Seats are the indices of
+// Game.Players.
+type HandHistory struct {
+	GameType  string         `json:"game_type"`
+	Betting   *BettingRecord `json:"betting,omitempty"`
+	Deck      []string       `json:"deck,omitempty"` // Order of the deck the hand was dealt from, top card first
+	Button    int            `json:"button"`
+	Seats     []SeatRecord   `json:"seats"`
+	BurnCards []string       `json:"burn_cards"`
+	Board     []string       `json:"board"`
+	Actions   []ActionRecord `json:"actions"`
+	Result    *ResultRecord  `json:"result,omitempty"`
+}
+
+// BettingRecord is the betting configuration of a recorded hand
+type BettingRecord struct {
+	Structure  string `json:"structure"`
+	SmallBlind int    `json:"small_blind"`
+	BigBlind   int    `json:"big_blind"`
+	Ante       int    `json:"ante"`
+	RaiseCap   int    `json:"raise_cap"`
+}
+
+// SeatRecord is a player's starting stack and hole cards
+type SeatRecord struct {
+	Seat  int      `json:"seat"`
+	ID    int      `json:"id"`
+	Stack int      `json:"stack"`
+	Cards []string `json:"cards"`
+}
+
+// ActionRecord is a betting action; Amount is the player's total bet on the street after it
+type ActionRecord struct {
+	Street string `json:"street"`
+	Player int    `json:"player"`
+	Type   string `json:"type"`
+	Amount int    `json:"amount,omitempty"`
+}
+
+// ResultRecord is the outcome of the showdown
+type ResultRecord struct {
+	Showdown []int       `json:"showdown,omitempty"` // Seats that showed their cards
+	Pots     []PotRecord `json:"pots"`
+	Payouts  []int       `json:"payouts"`
+	Stacks   []int       `json:"final_stacks"`
+}
+
+// PotRecord is the amount and winners of a pot
+type PotRecord struct {
+	Amount   int   `json:"amount"`
+	Eligible []int `json:"eligible"`
+	Winners  []int `json:"winners"`
+}
+
+// SetRecording turns the recording of hand histories on or off, from the next StartHand.
+func (g *Game) SetRecording(enabled bool) {
+	g.recording = enabled
+	if !enabled {
+		g.history = nil
+	}
+}
+
+// History returns the history of the current hand, or nil if recording is off.
+func (g *Game) History() *HandHistory {
+	return g.history
+}
+
+// startHistory begins the history of a hand once the hole cards are dealt and
+// before any forced bets are posted.
+func (g *Game) startHistory(deckOrder []*deck.Card) {
+	h := &HandHistory{
+		GameType:  g.gameType.String(),
+		Deck:      asciiCards(deckOrder),
+		Button:    g.Button,
+		BurnCards: []string{},
+		Board:     []string{},
+		Actions:   []ActionRecord{},
+	}
+	if g.betting != nil {
+		h.Betting = &BettingRecord{
+			Structure:  g.betting.Structure.String(),
+			SmallBlind: g.betting.SmallBlind,
+			BigBlind:   g.betting.BigBlind,
+			Ante:       g.betting.Ante,
+			RaiseCap:   g.betting.RaiseCap,
+		}
+	}
+	for i, p := range g.Players {
+		h.Seats = append(h.Seats, SeatRecord{Seat: i, ID: p.ID, Stack: p.Chips, Cards: asciiCards(p.Cards)})
+	}
+	g.history = h
+}
+
+func (g *Game) recordDeal(burn *deck.Card, cards []*deck.Card) {
+	if g.history == nil {
+		return
+	}
+	g.history.BurnCards = append(g.history.BurnCards, asciiCard(burn))
+	g.history.Board = append(g.history.Board, asciiCards(cards)...)
+}
+
+func (g *Game) recordAction(street Street, action Action) {
+	if g.history == nil {
+		return
+	}
+	record := ActionRecord{Street: street.String(), Player: action.Player, Type: action.Type.String()}
+	if action.Type != Fold && action.Type != Check {
+		record.Amount = action.Amount
+	}
+	g.history.Actions = append(g.history.Actions, record)
+}
+
+func (g *Game) recordShowdown(result *ShowdownResult) {
+	if g.history == nil {
+		return
+	}
+	r := &ResultRecord{Payouts: result.Payouts}
+	if active := g.activeSeats(); len(active) > 1 {
+		r.Showdown = active
+	}
+	for _, pot := range result.Pots {
+		r.Pots = append(r.Pots, PotRecord{Amount: pot.Amount, Eligible: pot.Eligible, Winners: pot.Winners})
+	}
+	for _, p := range g.Players {
+		r.Stacks = append(r.Stacks, p.Chips)
+	}
+	g.history.Result = r
+}
+
+// JSON returns the hand history as indented JSON
+func (h *HandHistory) JSON() ([]byte, error) {
+	return json.MarshalIndent(h, "", "  ")
+}
+
+// PHH returns the hand history in the Poker Hand History text format.
+// PHH numbers the players p1, p2, ... from the first seat left of the button, so
+// the button is the last player. Burn cards are not part of the format, and games
+// without a PHH variant, such as pot-limit Texas hold'em, cannot be exported.
+func (h *HandHistory) PHH() (string, error) {
+	n := len(h.Seats)
+	order := make([]int, n) // PHH player index to seat
+	for i := range order {
+		order[i] = (h.Button + 1 + i) % n
+	}
+	player := func(seat int) string {
+		return fmt.Sprintf("p%d", (seat-h.Button-1+2*n)%n+1)
+	}
+
+	var sb strings.Builder
+	betting := BettingRecord{Structure: NoLimit.String()}
+	if h.Betting != nil {
+		betting = *h.Betting
+	}
+	variant, ok := phhVariants[[2]string{betting.Structure, h.GameType}]
+	if !ok {
+		return "", fmt.Errorf("PHH does not define a variant for %s %s hold'em", betting.Structure, h.GameType)
+	}
+	fmt.Fprintf(&sb, "variant = %q\n", variant)
+
+	antes := make([]int, n)
+	blinds := make([]int, n)
+	stacks := make([]int, n)
+	for i, seat := range order {
+		antes[i] = betting.Ante
+		stacks[i] = h.Seats[seat].Stack
+	}
+	if n > 1 {
+		// Heads-up the button posts the small blind
+		smallBlind, bigBlind := 0, 1
+		if n == 2 {
+			smallBlind, bigBlind = 1, 0
+		}
+		blinds[smallBlind] = betting.SmallBlind
+		blinds[bigBlind] = betting.BigBlind
+	}
+	fmt.Fprintf(&sb, "antes = %s\n", phhInts(antes))
+	fmt.Fprintf(&sb, "blinds_or_straddles = %s\n", phhInts(blinds))
+	if betting.Structure == FixedLimit.String() {
+		fmt.Fprintf(&sb, "small_bet = %d\n", betting.BigBlind)
+		fmt.Fprintf(&sb, "big_bet = %d\n", 2*betting.BigBlind)
+	} else {
+		fmt.Fprintf(&sb, "min_bet = %d\n", betting.BigBlind)
+	}
+	fmt.Fprintf(&sb, "starting_stacks = %s\n", phhInts(stacks))
+
+	var actions []string
+	for _, seat := range order {
+		actions = append(actions, fmt.Sprintf("d dh %s %s", player(seat), strings.Join(h.Seats[seat].Cards, "")))
+	}
+	boardCards := []int{0, 3, 4, 5}
+	for street := Preflop; street <= River; street++ {
+		if street > Preflop {
+			if len(h.Board) < boardCards[street] {
+				break
+			}
+			actions = append(actions, "d db "+strings.Join(h.Board[boardCards[street-1]:boardCards[street]], ""))
+		}
+		for i, a := range h.Actions {
+			if a.Street != street.String() {
+				continue
+			}
+			switch {
+			case a.Type == Fold.String():
+				actions = append(actions, player(a.Player)+" f")
+			case a.Type == Bet.String() || a.Type == Raise.String() || (a.Type == AllIn.String() && h.raisesTo(i)):
+				actions = append(actions, fmt.Sprintf("%s cbr %d", player(a.Player), a.Amount))
+			default:
+				actions = append(actions, player(a.Player)+" cc")
+			}
+		}
+	}
+	if h.Result != nil {
+		for _, seat := range h.Result.Showdown {
+			actions = append(actions, fmt.Sprintf("%s sm %s", player(seat), strings.Join(h.Seats[seat].Cards, "")))
+		}
+	}
+
+	quoted := make([]string, len(actions))
+	for i, a := range actions {
+		quoted[i] = fmt.Sprintf("%q", a)
+	}
+	fmt.Fprintf(&sb, "actions = [%s]\n", strings.Join(quoted, ", "))
+
+	if h.Result != nil {
+		finishing := make([]int, n)
+		for i, seat := range order {
+			finishing[i] = h.Result.Stacks[seat]
+		}
+		fmt.Fprintf(&sb, "finishing_stacks = %s\n", phhInts(finishing))
+	}
+	return sb.String(), nil
+}
+
+// raisesTo reports whether the i-th action, an all-in, raised the highest bet on its street
+func (h *HandHistory) raisesTo(i int) bool {
+	action := h.Actions[i]
+	highest := 0
+	if action.Street == Preflop.String() && h.Betting != nil {
+		highest = h.Betting.BigBlind
+	}
+	for _, a := range h.Actions[:i] {
+		if a.Street == action.Street && a.Amount > highest {
+			highest = a.Amount
+		}
+	}
+	return action.Amount > highest
+}
+
+// phhVariants maps the betting structure and game type to the PHH variant code
+var phhVariants = map[[2]string]string{
+	{NoLimit.String(), Texas.String()}:    "NT",
+	{FixedLimit.String(), Texas.String()}: "FT",
+	{NoLimit.String(), Short.String()}:    "NS",
+	{PotLimit.String(), Omaha.String()}:   "PO",
+}
+
+func phhInts(values []int) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = fmt.Sprint(v)
+	}
+	return "[" + strings.Join(s, ", ") + "]"
+}
+
+// asciiCard returns the card in ASCII notation, e.g. "Th" for the ten of hearts
+func asciiCard(card *deck.Card) string {
+	value := card.Value
+	if value == "10" {
+		value = "T"
+	}
+	suit := map[string]string{"♠": "s", "♥": "h", "♦": "d", "♣": "c"}[card.Suit]
+	return value + suit
+}
+
+func asciiCards(cards []*deck.Card) []string {
+	s := make([]string, len(cards))
+	for i, card := range cards {
+		s[i] = asciiCard(card)
+	}
+	return s
+}
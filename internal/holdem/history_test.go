@@ -0,0 +1,163 @@
+package holdem
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordHandHistory(t *testing.T) {
+	game := NewGame(Texas, 3)
+	for i := range game.Players {
+		game.Players[i].ID = i + 1
+		game.Players[i].Chips = 100
+	}
+	assert.NoError(t, game.SetBetting(noLimit))
+	game.SetRecording(true)
+	assert.NoError(t, game.StartHand())
+
+	act(t, game,
+		Action{Player: 0, Type: Raise, Amount: 6},
+		Action{Player: 1, Type: Fold},
+		Action{Player: 2, Type: Call},
+	)
+	assert.NoError(t, game.DealFlop())
+	act(t, game,
+		Action{Player: 2, Type: Check},
+		Action{Player: 0, Type: Bet, Amount: 10},
+		Action{Player: 2, Type: Call},
+	)
+	assert.NoError(t, game.DealTurnOrRiver())
+	act(t, game, Action{Player: 2, Type: Check}, Action{Player: 0, Type: Check})
+	assert.NoError(t, game.DealTurnOrRiver())
+	act(t, game, Action{Player: 2, Type: AllIn}, Action{Player: 0, Type: Call})
+	result, err := game.Showdown(NewSmartHandRanker())
+	assert.NoError(t, err)
+
+	h := game.History()
+	assert.NotNil(t, h)
+	assert.Equal(t, "texas", h.GameType)
+	assert.Equal(t, "no-limit", h.Betting.Structure)
+	assert.Len(t, h.Seats, 3)
+	for i, seat := range h.Seats {
+		assert.Equal(t, i, seat.Seat)
+		assert.Equal(t, i+1, seat.ID)
+		assert.Equal(t, 100, seat.Stack)
+		assert.Equal(t, asciiCards(game.Players[i].Cards), seat.Cards)
+	}
+	assert.Len(t, h.BurnCards, 3)
+	assert.Equal(t, asciiCards(game.Community), h.Board)
+	assert.Equal(t, []ActionRecord{
+		{Street: "preflop", Player: 0, Type: "raise", Amount: 6},
+		{Street: "preflop", Player: 1, Type: "fold"},
+		{Street: "preflop", Player: 2, Type: "call", Amount: 6},
+		{Street: "flop", Player: 2, Type: "check"},
+		{Street: "flop", Player: 0, Type: "bet", Amount: 10},
+		{Street: "flop", Player: 2, Type: "call", Amount: 10},
+		{Street: "turn", Player: 2, Type: "check"},
+		{Street: "turn", Player: 0, Type: "check"},
+		{Street: "river", Player: 2, Type: "all-in", Amount: 84},
+		{Street: "river", Player: 0, Type: "call", Amount: 84},
+	}, h.Actions)
+	assert.Equal(t, []int{0, 2}, h.Result.Showdown)
+	assert.Equal(t, result.Payouts, h.Result.Payouts)
+	assert.Equal(t, 201, h.Result.Pots[0].Amount)
+
+	// The JSON export round trips
+	data, err := h.JSON()
+	assert.NoError(t, err)
+	var decoded HandHistory
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, *h, decoded)
+
+	// A new hand starts a new history
+	for i := range game.Players {
+		game.Players[i].Chips = 100
+	}
+	game.Button = 1
+	assert.NoError(t, game.StartHand())
+	assert.NotSame(t, h, game.History())
+	assert.Empty(t, game.History().Actions)
+}
+
+func TestHandHistoryDisabled(t *testing.T) {
+	game := NewGame(Texas, 2)
+	assert.NoError(t, game.StartHand())
+	assert.Nil(t, game.History())
+
+	game.SetRecording(true)
+	assert.NoError(t, game.StartHand())
+	assert.NotNil(t, game.History())
+
+	game.SetRecording(false)
+	assert.Nil(t, game.History())
+}
+
+func TestHandHistoryPHH(t *testing.T) {
+	h := &HandHistory{
+		GameType: "texas",
+		Betting:  &BettingRecord{Structure: "no-limit", SmallBlind: 1, BigBlind: 2},
+		Button:   0,
+		Seats: []SeatRecord{
+			{Seat: 0, Stack: 100, Cards: []string{"Qc", "Qd"}},
+			{Seat: 1, Stack: 100, Cards: []string{"As", "Ks"}},
+			{Seat: 2, Stack: 50, Cards: []string{"7h", "2c"}},
+		},
+		BurnCards: []string{"3d", "4d", "5d"},
+		Board:     []string{"Ah", "Td", "6s", "8c", "Jh"},
+		Actions: []ActionRecord{
+			{Street: "preflop", Player: 0, Type: "raise", Amount: 6},
+			{Street: "preflop", Player: 1, Type: "call", Amount: 6},
+			{Street: "preflop", Player: 2, Type: "fold"},
+			{Street: "flop", Player: 1, Type: "all-in", Amount: 94},
+			{Street: "flop", Player: 0, Type: "call", Amount: 94},
+		},
+		Result: &ResultRecord{
+			Showdown: []int{0, 1},
+			Pots:     []PotRecord{{Amount: 202, Eligible: []int{0, 1}, Winners: []int{1}}},
+			Payouts:  []int{0, 202, 0},
+			Stacks:   []int{0, 202, 48},
+		},
+	}
+
+	expected := `variant = "NT"
+antes = [0, 0, 0]
+blinds_or_straddles = [1, 2, 0]
+min_bet = 2
+starting_stacks = [100, 50, 100]
+actions = ["d dh p1 AsKs", "d dh p2 7h2c", "d dh p3 QcQd", "p3 cbr 6", "p1 cc", "p2 f", "d db AhTd6s", "p1 cbr 94", "p3 cc", "d db 8c", "d db Jh", "p3 sm QcQd", "p1 sm AsKs"]
+finishing_stacks = [202, 48, 0]
+`
+	phh, err := h.PHH()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, phh)
+
+	t.Run("Heads-up fixed-limit", func(t *testing.T) {
+		h := &HandHistory{
+			GameType: "texas",
+			Betting:  &BettingRecord{Structure: "fixed-limit", SmallBlind: 1, BigBlind: 2},
+			Button:   1,
+			Seats: []SeatRecord{
+				{Seat: 0, Stack: 100, Cards: []string{"As", "Ks"}},
+				{Seat: 1, Stack: 100, Cards: []string{"Qc", "Qd"}},
+			},
+			Actions: []ActionRecord{{Street: "preflop", Player: 1, Type: "fold"}},
+		}
+		expected := `variant = "FT"
+antes = [0, 0]
+blinds_or_straddles = [2, 1]
+small_bet = 2
+big_bet = 4
+starting_stacks = [100, 100]
+actions = ["d dh p1 AsKs", "d dh p2 QcQd", "p2 f"]
+`
+		phh, err := h.PHH()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, phh)
+
+		h.GameType = "short"
+		_, err = h.PHH()
+		assert.ErrorContains(t, err, "fixed-limit short", "PHH has no fixed-limit short deck variant")
+	})
+}
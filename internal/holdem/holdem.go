@@ -4,6 +4,7 @@ package holdem
 
 import (
 	"fmt"
+	"math/rand"
 	"regexp"
 	"strings"
 
@@ -37,6 +38,20 @@ func (g GameType) String() string {
 	}
 }
 
+// HoleCards returns the number of hole cards each player is dealt in the game type
+func (g GameType) HoleCards() int {
+	if g == Omaha {
+		return 4
+	}
+	return 2
+}
+
+// HasCard reports whether the card is in the deck of the game type.
+// The Short deck has cards 2-5 removed.
+func (g GameType) HasCard(card *deck.Card) bool {
+	return g != Short || highCardsPattern.MatchString(card.Value)
+}
+
 // AllGameTypes returns a slice of all available game types
 func AllGameTypes() []GameType {
 	return []GameType{Texas, Omaha, Short}
@@ -65,6 +80,7 @@ type Game struct {
 	dealer   dealer.DealStrategy
 	deck     *deck.Deck
 	gameType GameType
+	source   rand.Source // Source of randomness for shuffling; nil seeds from the current time
 
 	// Players contains all players in the game
 	Players []Player
@@ -72,56 +88,92 @@ type Game struct {
 	// Community contains the community cards on the table
 	Community []*deck.Card
 
+	// Button is the seat of the dealer button, which moves the blinds and the order of action
+	Button int
+
 	burnCards []*deck.Card
+
+	// Betting state, used once SetBetting is called
+	betting    *BettingConfig
+	street     Street
+	pot        int  // Chips gathered from previous streets, antes and AddToPot
+	toAct      int  // Seat of the player to act, or -1
+	currentBet int  // Highest bet on the current street
+	lastRaise  int  // Size of the last full bet or raise, the minimum raise increment
+	raises     int  // Number of full bets and raises on the current street
+	settled    bool // Whether the pots have been paid out by Showdown
+
+	stacked   bool         // Whether the next hand deals the deck set by StackDeck unshuffled
+	recording bool         // Whether to record a history of each hand
+	history   *HandHistory // History of the current hand, when recording
 }
 
 // Player represents a poker player with their hole cards and chip stack.
+// Chips is the stack behind; chips bet on the current street are held in Bet.
 type Player struct {
-	ID    int
-	Cards []*deck.Card
-	Chips int
+	ID          int
+	Cards       []*deck.Card
+	Chips       int
+	Bet         int  // Chips bet on the current street
+	Contributed int  // Chips put in the pot this hand, including antes, blinds and Bet
+	Folded      bool // Whether the player has folded this hand
+	AllIn       bool // Whether the player has no chips left behind
+
+	acted   bool // Whether the player has acted on the current street
+	actedAt int  // Current bet when the player last acted, to tell if the betting was reopened
 }
 
-// NewGame creates a new Hold'em game instance with the specified game type and number of players.
-// It initializes a fresh deck based on the game type, dealer, and empty community cards.
-func NewGame(gameType GameType, numPlayers int) *Game {
-	d := deck.NewDeck()
+// newGameDeck builds the deck used by the game type, excluding cards that match the masks.
+// The Short deck has cards 2-5 removed.
+func newGameDeck(gameType GameType, masks ...string) *deck.Deck {
+	d := deck.NewDeck(masks...)
 
 	// For Short deck, remove cards 2-5
 	if gameType == Short {
 		newCards := make([]*deck.Card, 0)
 		for _, card := range d.Cards {
-			if highCardsPattern.MatchString(card.Value) {
+			if gameType.HasCard(card) {
 				newCards = append(newCards, card)
 			}
 		}
 		d.Cards = newCards
 	}
+	return d
+}
 
+// NewGame creates a new Hold'em game instance with the specified game type and number of players.
+// It initializes a fresh deck based on the game type, dealer, and empty community cards.
+func NewGame(gameType GameType, numPlayers int) *Game {
 	return &Game{
 		dealer:    &dealer.StandardDealer{},
-		deck:      d,
+		deck:      newGameDeck(gameType),
 		gameType:  gameType,
 		Players:   make([]Player, numPlayers),
 		Community: make([]*deck.Card, 0, 5),
+		toAct:     -1,
 	}
 }
 
-// StartHand begins a new hand by shuffling the deck and dealing cards to each player.
+// StartHand begins a new hand by shuffling a fresh deck and dealing cards to each player.
 // The number of cards dealt depends on the game type (2 for Texas/Short, 4 for Omaha).
+// A deck set with StackDeck is dealt as it is instead.
+// When betting is configured, the antes and blinds are posted and pre-flop betting opens.
 // Returns an error if dealing fails.
 func (g *Game) StartHand() error {
-	g.deck.Shuffle()
-	g.Community = g.Community[:0]
-
-	// Determine number of hole cards based on game type
-	numCards := 2
-	if g.gameType == Omaha {
-		numCards = 4
+	if g.stacked {
+		g.stacked = false
+	} else {
+		g.deck = newGameDeck(g.gameType)
+		g.deck.SetSource(g.source)
+		g.deck.Shuffle()
 	}
+	deckOrder := g.deck.Cards
+	g.Community = g.Community[:0]
+	g.burnCards = g.burnCards[:0]
+	g.settled = false
 
 	// Deal cards to each player
-	hands, err := g.dealer.Deal(g.deck, numCards, len(g.Players))
+	hands, err := g.dealer.Deal(g.deck, g.gameType.HoleCards(), len(g.Players))
 	if err != nil {
 		return err
 	}
@@ -129,6 +181,45 @@ func (g *Game) StartHand() error {
 	for i := range g.Players {
 		g.Players[i].Cards = hands[i].Cards
 	}
+
+	if g.recording {
+		g.startHistory(deckOrder)
+	}
+	if g.betting != nil {
+		return g.startBetting()
+	}
+	return nil
+}
+
+// SetSource sets the source of randomness used to shuffle the deck for each hand,
+// so that a source in the same state deals the same hands. A nil src restores
+// seeding each shuffle with the current time.
+func (g *Game) SetSource(src rand.Source) {
+	g.source = src
+}
+
+// Seed makes the hands dealt reproducible from the given seed
+func (g *Game) Seed(seed int64) {
+	g.SetSource(rand.NewSource(seed))
+}
+
+// StackDeck sets the order of the cards the next hand is dealt from, top card first.
+// StartHand then deals them without shuffling, which lets a recorded hand be replayed.
+// Returns an error if a card is not part of the game type's deck or appears twice.
+func (g *Game) StackDeck(cards []*deck.Card) error {
+	available := make(map[string]bool)
+	for _, card := range newGameDeck(g.gameType).Cards {
+		available[card.String()] = true
+	}
+	for _, card := range cards {
+		if !available[card.String()] {
+			return fmt.Errorf("card %s is not in the deck or appears twice", card)
+		}
+		delete(available, card.String())
+	}
+
+	g.deck = &deck.Deck{Cards: append([]*deck.Card{}, cards...)}
+	g.stacked = true
 	return nil
 }
 
@@ -154,21 +245,90 @@ func (g *Game) DealCommunityCards(numCards int) error {
 		return err
 	}
 	g.Community = append(g.Community, hands[0].Cards...)
+	g.recordDeal(g.burnCards[len(g.burnCards)-1], hands[0].Cards)
 	return nil
 }
 
 // DealFlop deals the first three community cards (the flop) after burning one card.
+// When betting is configured, the pre-flop round must be complete and the flop betting opens.
 // Returns an error if dealing fails.
 func (g *Game) DealFlop() error {
-	return g.DealCommunityCards(3)
+	return g.dealStreet(Flop, 3)
 }
 
 // DealTurnOrRiver deals one community card (either the turn or river) after burning one card.
+// When betting is configured, the previous round must be complete and the new street's betting opens.
 // Returns an error if dealing fails.
 func (g *Game) DealTurnOrRiver() error {
-	return g.DealCommunityCards(1)
+	street := Turn
+	if len(g.Community) > 3 {
+		street = River
+	}
+	return g.dealStreet(street, 1)
+}
+
+func (g *Game) dealStreet(street Street, numCards int) error {
+	if err := g.checkStreet(street); err != nil {
+		return err
+	}
+	if err := g.DealCommunityCards(numCards); err != nil {
+		return err
+	}
+	if g.betting != nil {
+		g.startStreet(street)
+	}
+	return nil
 }
 
 // AddToPot adds the specified amount to the current pot.
 func (g *Game) AddToPot(amount int) {
+	g.pot += amount
+}
+
+// ParseCards parses cards in the notation of deck.ParseCards, such as "As Kh" or "A♠ 10♥",
+// rejecting jokers, which Hold'em does not use, and cards missing from the game type's deck.
+func ParseCards(gameType GameType, notation string) ([]*deck.Card, error) {
+	cards, err := deck.ParseCards(notation)
+	if err != nil {
+		return nil, err
+	}
+	for _, card := range cards {
+		if err := checkPokerCard(card); err != nil {
+			return nil, err
+		}
+		if !gameType.HasCard(card) {
+			return nil, fmt.Errorf("invalid card %s: cards 2-5 are not used in %s", card, gameType)
+		}
+	}
+	return cards, nil
+}
+
+// parseCard parses a card with deck.ParseCard, rejecting jokers
+func parseCard(s string) (*deck.Card, error) {
+	card, err := deck.ParseCard(s)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPokerCard(card); err != nil {
+		return nil, err
+	}
+	return card, nil
+}
+
+func parseCardList(s []string) ([]*deck.Card, error) {
+	cards := make([]*deck.Card, len(s))
+	for i, card := range s {
+		var err error
+		if cards[i], err = parseCard(card); err != nil {
+			return nil, err
+		}
+	}
+	return cards, nil
+}
+
+func checkPokerCard(card *deck.Card) error {
+	if _, ok := valueToRank[card.Value]; !ok {
+		return fmt.Errorf("invalid card %s: jokers are not used in Hold'em", card)
+	}
+	return nil
 }
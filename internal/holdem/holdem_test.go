@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/genewoo/joker/internal/dealer"
+	"github.com/genewoo/joker/internal/deck"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -273,3 +274,22 @@ func TestParseGameType(t *testing.T) {
 		})
 	}
 }
+
+func TestParseCards(t *testing.T) {
+	cards, err := ParseCards(Texas, "As 10♥ Td")
+	assert.NoError(t, err)
+	assert.Equal(t, []*deck.Card{deck.NewCard("A", "♠"), deck.NewCard("10", "♥"), deck.NewCard("10", "♦")}, cards)
+
+	_, err = ParseCards(Texas, "As Zx")
+	assert.Error(t, err)
+	_, err = ParseCards(Texas, "As RJ")
+	assert.ErrorContains(t, err, "jokers")
+
+	cards, err = ParseCards(Short, "As 6h")
+	assert.NoError(t, err)
+	assert.Len(t, cards, 2)
+	_, err = ParseCards(Short, "As 5h")
+	assert.ErrorContains(t, err, "not used in short")
+	_, err = ParseCards(Texas, "As 5h")
+	assert.NoError(t, err)
+}
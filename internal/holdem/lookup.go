@@ -0,0 +1,350 @@
+package holdem
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/genewoo/joker/internal/deck"
+)
+
+// LookupHandRanker implements HandRanker with precomputed lookup tables in the style of
+// Cactus Kev's evaluator. Cards are packed into PackedCard and each 5-card hand is mapped
+// to one of its 7462 equivalence classes with a table lookup on its rank bits, or a
+// binary search on the product of its rank primes. Hands of more cards take the best of
+// their 5-card combinations.
+type LookupHandRanker struct {
+	shortDeck ShortDeckRules
+}
+
+// NewLookupHandRanker creates a new LookupHandRanker instance.
+// The tables of a game variant are built the first time a hand of it is ranked.
+func NewLookupHandRanker() *LookupHandRanker {
+	return &LookupHandRanker{}
+}
+
+// RankHand evaluates the best 5-card hand from a player's hole cards
+// and 5 community cards using the lookup tables.
+// Texas and Short hands use 2 hole cards; Omaha hands use exactly two of
+// the 4 hole cards and exactly three community cards.
+// Returns the hand strength and the best 5 cards that form the hand.
+func (r *LookupHandRanker) RankHand(gameType GameType, playerCards []*deck.Card, communityCards []*deck.Card) (HandStrength, []*deck.Card) {
+	if gameType == Omaha {
+		return rankOmaha(playerCards, communityCards, func(cards []*deck.Card) (HandStrength, []*deck.Card) {
+			return rankWithTables(standardTables(), cards, nil)
+		})
+	}
+
+	if len(playerCards) != 2 || len(communityCards) != 5 {
+		return invalidHand()
+	}
+
+	cards := make([]*deck.Card, 0, 7)
+	cards = append(append(cards, playerCards...), communityCards...)
+	if gameType == Short {
+		return rankWithTables(shortDeckTables(r.shortDeck), cards, &r.shortDeck)
+	}
+	return rankWithTables(standardTables(), cards, nil)
+}
+
+// SetShortDeckRules sets the ranking variations applied to Short game hands.
+func (r *LookupHandRanker) SetShortDeckRules(rules ShortDeckRules) {
+	r.shortDeck = rules
+}
+
+// rankWithTables finds the best 5-card hand among five or more cards.
+// shortDeck sets the hand category order of the strength when not nil.
+func rankWithTables(tables *lookupTables, cards []*deck.Card, shortDeck *ShortDeckRules) (HandStrength, []*deck.Card) {
+	var buffer [7]PackedCard
+	packed := buffer[:0]
+	for _, card := range cards {
+		p, err := PackCard(card)
+		if err != nil {
+			return invalidHand()
+		}
+		packed = append(packed, p)
+	}
+
+	best, bestCombo := tables.bestHand(packed)
+	if best == 0 {
+		return invalidHand()
+	}
+	class := tables.classes[best-1]
+	strength := HandStrength{
+		Rank:   class.rank,
+		Values: append([]int(nil), class.values...),
+		order:  shortDeck.order(),
+	}
+
+	// The best hand is ordered by rank, highest first
+	combo := bestCombo[:]
+	sort.SliceStable(combo, func(i, j int) bool {
+		return packed[combo[i]].Rank() > packed[combo[j]].Rank()
+	})
+	bestHand := make([]*deck.Card, len(combo))
+	for i, card := range combo {
+		bestHand[i] = cards[card]
+	}
+	return strength, bestHand
+}
+
+// lookupClass is an equivalence class of 5-card hands: all hands of a class have the same strength
+type lookupClass struct {
+	rank   HandRank
+	values []int
+}
+
+// lookupTables map 5-card hands to their equivalence class, numbered from 1 for the best class.
+type lookupTables struct {
+	flushes  [1 << 13]uint16 // Class of a flush by its rank bits
+	unique   [1 << 13]uint16 // Class of five distinct ranks of mixed suits by their rank bits
+	products []uint32        // Sorted prime products of the hands with a repeated rank
+	values   []uint16        // Class of each product in products
+	classes  []lookupClass   // Classes by their number minus one
+}
+
+// evaluate returns the class of a 5-card hand, or 0 if no class has its ranks,
+// as with five cards of the same rank from several decks
+func (t *lookupTables) evaluate(a, b, c, d, e PackedCard) uint16 {
+	bits := (a | b | c | d | e) >> 16
+	if a&b&c&d&e&0xF000 != 0 {
+		return t.flushes[bits]
+	}
+	if class := t.unique[bits]; class != 0 {
+		return class
+	}
+	product := uint32(a&0xFF) * uint32(b&0xFF) * uint32(c&0xFF) * uint32(d&0xFF) * uint32(e&0xFF)
+	i := sort.Search(len(t.products), func(i int) bool { return t.products[i] >= product })
+	if i == len(t.products) || t.products[i] != product {
+		return 0
+	}
+	return t.values[i]
+}
+
+// bestHand returns the best class among the 5-card combinations of the cards
+// and the indices of the cards that make it, or 0 if none has a class.
+func (t *lookupTables) bestHand(cards []PackedCard) (uint16, [5]int) {
+	var best uint16
+	var bestCombo [5]int
+	n := len(cards)
+	for a := 0; a < n; a++ {
+		for b := a + 1; b < n; b++ {
+			for c := b + 1; c < n; c++ {
+				for d := c + 1; d < n; d++ {
+					for e := d + 1; e < n; e++ {
+						class := t.evaluate(cards[a], cards[b], cards[c], cards[d], cards[e])
+						if class != 0 && (best == 0 || class < best) {
+							best = class
+							bestCombo = [5]int{a, b, c, d, e}
+						}
+					}
+				}
+			}
+		}
+	}
+	return best, bestCombo
+}
+
+var (
+	standardOnce   sync.Once
+	standard       *lookupTables
+	shortDeckOnce  [2]sync.Once
+	shortDeckTable [2]*lookupTables
+)
+
+// standardTables returns the tables of the standard Hold'em hand ranking
+func standardTables() *lookupTables {
+	standardOnce.Do(func() {
+		standard = newLookupTables(nil)
+	})
+	return standard
+}
+
+// shortDeckTables returns the tables of the short-deck hand ranking with the given rules
+func shortDeckTables(rules ShortDeckRules) *lookupTables {
+	i := 0
+	if rules.TripsBeatStraight {
+		i = 1
+	}
+	shortDeckOnce[i].Do(func() {
+		shortDeckTable[i] = newLookupTables(&rules)
+	})
+	return shortDeckTable[i]
+}
+
+// lookupStraight is a straight by the rank bits of its cards and its highest card
+type lookupStraight struct {
+	bits int
+	high int
+}
+
+// newLookupTables numbers every 5-card hand class from the best down, following the
+// hand category order of the rules; nil rules mean standard Hold'em.
+func newLookupTables(shortDeck *ShortDeckRules) *lookupTables {
+	t := &lookupTables{}
+	products := make(map[uint32]uint16)
+	addClass := func(rank HandRank, values ...int) uint16 {
+		t.classes = append(t.classes, lookupClass{rank: rank, values: values})
+		return uint16(len(t.classes))
+	}
+	product := func(counts map[int]int) uint32 {
+		p := uint32(1)
+		for rank, count := range counts {
+			for i := 0; i < count; i++ {
+				p *= rankPrimes[rank-2]
+			}
+		}
+		return p
+	}
+	bitsOf := func(ranks []int) int {
+		bits := 0
+		for _, rank := range ranks {
+			bits |= 1 << (rank - 2)
+		}
+		return bits
+	}
+
+	// Straights from the ace-high down. The wheel A-2-3-4-5 is the lowest straight
+	// in Hold'em; short deck replaces it with A-6-7-8-9, which plays as 9-high.
+	var straights []lookupStraight
+	for high := 14; high >= 6; high-- {
+		straights = append(straights, lookupStraight{bits: 0x1F << (high - 6), high: high})
+	}
+	if shortDeck != nil {
+		straights = append(straights, lookupStraight{bits: bitsOf([]int{14, 6, 7, 8, 9}), high: 9})
+	} else {
+		straights = append(straights, lookupStraight{bits: bitsOf([]int{14, 2, 3, 4, 5}), high: 5})
+	}
+	isStraight := make(map[int]bool)
+	for _, s := range straights {
+		isStraight[s.bits] = true
+	}
+
+	// Five distinct ranks that make no straight, from the highest
+	var distinct [][]int
+	var choose func(ranks []int, below int)
+	choose = func(ranks []int, below int) {
+		if len(ranks) == 5 {
+			if !isStraight[bitsOf(ranks)] {
+				distinct = append(distinct, append([]int(nil), ranks...))
+			}
+			return
+		}
+		for rank := below - 1; rank >= 2; rank-- {
+			choose(append(ranks, rank), rank)
+		}
+	}
+	choose(nil, 15)
+
+	// kickers returns the ranks other than the excluded ones, highest first, in
+	// groups of n in descending order
+	kickers := func(n int, excluded ...int) [][]int {
+		var groups [][]int
+		var pick func(group []int, below int)
+		pick = func(group []int, below int) {
+			if len(group) == n {
+				groups = append(groups, append([]int(nil), group...))
+				return
+			}
+		next:
+			for rank := below - 1; rank >= 2; rank-- {
+				for _, e := range excluded {
+					if rank == e {
+						continue next
+					}
+				}
+				pick(append(group, rank), rank)
+			}
+		}
+		pick(nil, 15)
+		return groups
+	}
+
+	straightFlushes := func() {
+		classes := make(map[int]uint16)
+		for _, s := range straights {
+			if _, ok := classes[s.high]; !ok {
+				if s.high == 14 {
+					classes[s.high] = addClass(RoyalFlush, 14, 13, 12, 11, 10)
+				} else {
+					classes[s.high] = addClass(StraightFlush, s.high)
+				}
+			}
+			t.flushes[s.bits] = classes[s.high]
+		}
+	}
+	fourOfAKinds := func() {
+		for quad := 14; quad >= 2; quad-- {
+			for _, k := range kickers(1, quad) {
+				products[product(map[int]int{quad: 4, k[0]: 1})] = addClass(FourOfAKind, quad, k[0])
+			}
+		}
+	}
+	fullHouses := func() {
+		for three := 14; three >= 2; three-- {
+			for _, pair := range kickers(1, three) {
+				products[product(map[int]int{three: 3, pair[0]: 2})] = addClass(FullHouse, three, pair[0])
+			}
+		}
+	}
+	flushes := func() {
+		for _, ranks := range distinct {
+			t.flushes[bitsOf(ranks)] = addClass(Flush, ranks...)
+		}
+	}
+	plainStraights := func() {
+		classes := make(map[int]uint16)
+		for _, s := range straights {
+			if _, ok := classes[s.high]; !ok {
+				classes[s.high] = addClass(Straight, s.high)
+			}
+			t.unique[s.bits] = classes[s.high]
+		}
+	}
+	threeOfAKinds := func() {
+		for three := 14; three >= 2; three-- {
+			for _, k := range kickers(2, three) {
+				products[product(map[int]int{three: 3, k[0]: 1, k[1]: 1})] = addClass(ThreeOfAKind, three, k[0], k[1])
+			}
+		}
+	}
+	twoPairs := func() {
+		for _, pairs := range kickers(2) {
+			for _, k := range kickers(1, pairs...) {
+				products[product(map[int]int{pairs[0]: 2, pairs[1]: 2, k[0]: 1})] = addClass(TwoPair, pairs[0], pairs[1], k[0])
+			}
+		}
+	}
+	onePairs := func() {
+		for pair := 14; pair >= 2; pair-- {
+			for _, k := range kickers(3, pair) {
+				products[product(map[int]int{pair: 2, k[0]: 1, k[1]: 1, k[2]: 1})] = addClass(OnePair, pair, k[0], k[1], k[2])
+			}
+		}
+	}
+	highCards := func() {
+		for _, ranks := range distinct {
+			t.unique[bitsOf(ranks)] = addClass(HighCard, ranks...)
+		}
+	}
+
+	categories := []func(){straightFlushes, fourOfAKinds, fullHouses, flushes, plainStraights, threeOfAKinds, twoPairs, onePairs, highCards}
+	if shortDeck != nil {
+		categories[2], categories[3] = flushes, fullHouses
+		if shortDeck.TripsBeatStraight {
+			categories[4], categories[5] = threeOfAKinds, plainStraights
+		}
+	}
+	for _, category := range categories {
+		category()
+	}
+
+	for p := range products {
+		t.products = append(t.products, p)
+	}
+	sort.Slice(t.products, func(i, j int) bool { return t.products[i] < t.products[j] })
+	t.values = make([]uint16, len(t.products))
+	for i, p := range t.products {
+		t.values[i] = products[p]
+	}
+	return t
+}
@@ -0,0 +1,137 @@
+package holdem
+
+import (
+	"testing"
+
+	"github.com/genewoo/joker/internal/deck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupRankHand(t *testing.T) {
+	ranker := NewLookupHandRanker()
+	t.Parallel()
+	for _, tt := range handTestCases {
+		t.Run(tt.name, func(t *testing.T) {
+			rank, cards := ranker.RankHand(Texas, tt.playerCards, tt.communityCards)
+			if tt.expectedRank.Rank == InvalidHand {
+				assert.Nil(t, cards)
+			} else {
+				assert.Len(t, cards, 5)
+			}
+			assert.Equal(t, tt.expectedRank.Rank, rank.Rank)
+			if len(tt.expectedRank.Values) > 0 {
+				assert.Equal(t, tt.expectedRank.Values, rank.Values)
+			}
+		})
+	}
+}
+
+func TestLookupTables(t *testing.T) {
+	tables := standardTables()
+	assert.Len(t, tables.classes, 7462)
+
+	count := make(map[HandRank]int)
+	for _, class := range tables.classes {
+		count[class.rank]++
+	}
+	assert.Equal(t, map[HandRank]int{
+		RoyalFlush:    1,
+		StraightFlush: 9,
+		FourOfAKind:   156,
+		FullHouse:     156,
+		Flush:         1277,
+		Straight:      10,
+		ThreeOfAKind:  858,
+		TwoPair:       858,
+		OnePair:       2860,
+		HighCard:      1277,
+	}, count)
+
+	// Classes are numbered from the best hand down
+	for i := 1; i < len(tables.classes); i++ {
+		better := HandStrength{Rank: tables.classes[i-1].rank, Values: tables.classes[i-1].values}
+		worse := HandStrength{Rank: tables.classes[i].rank, Values: tables.classes[i].values}
+		assert.Equal(t, 1, better.Compare(worse), "class %d", i)
+	}
+}
+
+func TestLookupRankerMatchesSmartRanker(t *testing.T) {
+	tests := []struct {
+		name     string
+		gameType GameType
+		rules    ShortDeckRules
+	}{
+		{name: "Texas", gameType: Texas},
+		{name: "Omaha", gameType: Omaha},
+		{name: "Short", gameType: Short},
+		{name: "Short with trips above straights", gameType: Short, rules: ShortDeckRules{TripsBeatStraight: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookup, smart := NewLookupHandRanker(), NewSmartHandRanker()
+			lookup.SetShortDeckRules(tt.rules)
+			smart.SetShortDeckRules(tt.rules)
+
+			d := newGameDeck(tt.gameType)
+			d.Seed(1)
+			holeCards := tt.gameType.HoleCards()
+			for i := 0; i < 3000; i++ {
+				d.Shuffle()
+				hole, board := d.Cards[:holeCards], d.Cards[holeCards:holeCards+5]
+				expected, _ := smart.RankHand(tt.gameType, hole, board)
+				actual, cards := lookup.RankHand(tt.gameType, hole, board)
+				assert.Equal(t, expected.Rank, actual.Rank, "hole %v board %v", hole, board)
+				assert.Equal(t, expected.Values, actual.Values, "hole %v board %v", hole, board)
+				assert.Equal(t, 0, expected.Compare(actual))
+				assert.Len(t, cards, 5)
+			}
+		})
+	}
+}
+
+// benchmarkHands deals seeded 7-card hands for the ranker benchmarks
+func benchmarkHands(n int) [][]*deck.Card {
+	d := deck.NewDeck()
+	d.Seed(1)
+	hands := make([][]*deck.Card, n)
+	for i := range hands {
+		d.Shuffle()
+		hands[i] = append([]*deck.Card{}, d.Cards[:7]...)
+	}
+	return hands
+}
+
+func BenchmarkRankHand(b *testing.B) {
+	hands := benchmarkHands(1000)
+	rankers := []struct {
+		name   string
+		ranker HandRanker
+	}{
+		{name: "SmartHandRanker", ranker: NewSmartHandRanker()},
+		{name: "LookupHandRanker", ranker: NewLookupHandRanker()},
+	}
+
+	for _, r := range rankers {
+		b.Run(r.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				hand := hands[i%len(hands)]
+				r.ranker.RankHand(Texas, hand[:2], hand[2:])
+			}
+		})
+	}
+}
+
+func BenchmarkEvaluatePacked(b *testing.B) {
+	tables := standardTables()
+	hands := benchmarkHands(1000)
+	packed := make([][]PackedCard, len(hands))
+	for i, hand := range hands {
+		packed[i], _ = PackCards(hand)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tables.bestHand(packed[i%len(packed)])
+	}
+}
@@ -0,0 +1,120 @@
+package holdem
+
+import (
+	"fmt"
+
+	"github.com/genewoo/joker/internal/deck"
+)
+
+// PackedCard is a card packed into 32 bits in the Cactus Kev encoding:
+//
+//	xxxbbbbb bbbbbbbb cdhsrrrr xxpppppp
+//
+// where b is one bit set for the rank, cdhs is one bit set for the suit,
+// r is the rank from 0 (deuce) to 12 (ace) and p is the rank's prime.
+// The layout lets a 5-card hand be classified with bitwise operations and
+// a product of primes instead of maps and sorting.
+type PackedCard uint32
+
+// rankPrimes are the primes of the ranks 2 to A; the product of five of them
+// identifies a multiset of ranks regardless of order.
+var rankPrimes = [13]uint32{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41}
+
+// packedSuits are the suit bits of PackedCard, in the order c, d, h, s
+var packedSuits = [4]string{"♣", "♦", "♥", "♠"}
+
+// packedValues are the card values of the ranks 0 to 12
+var packedValues = [13]string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
+
+// PackCard packs a card. Returns an error for jokers and unknown values or suits.
+func PackCard(card *deck.Card) (PackedCard, error) {
+	rank := packedRank(card.Value)
+	if rank < 0 {
+		return 0, fmt.Errorf("cannot pack card %s: unknown value", card)
+	}
+	var suit uint32
+	switch card.Suit {
+	case "♣":
+		suit = 0x8000
+	case "♦":
+		suit = 0x4000
+	case "♥":
+		suit = 0x2000
+	case "♠":
+		suit = 0x1000
+	default:
+		return 0, fmt.Errorf("cannot pack card %s: unknown suit", card)
+	}
+	return PackedCard(1<<(16+rank) | suit | uint32(rank)<<8 | rankPrimes[rank]), nil
+}
+
+// PackCards packs the cards, or returns an error for the first card that cannot be packed.
+func PackCards(cards []*deck.Card) ([]PackedCard, error) {
+	packed := make([]PackedCard, len(cards))
+	for i, card := range cards {
+		var err error
+		if packed[i], err = PackCard(card); err != nil {
+			return nil, err
+		}
+	}
+	return packed, nil
+}
+
+// packedRank returns the rank of a card value from 0 (deuce) to 12 (ace), or -1.
+// A switch avoids the map lookup of valueToRank on the hot path.
+func packedRank(value string) int {
+	switch value {
+	case "2":
+		return 0
+	case "3":
+		return 1
+	case "4":
+		return 2
+	case "5":
+		return 3
+	case "6":
+		return 4
+	case "7":
+		return 5
+	case "8":
+		return 6
+	case "9":
+		return 7
+	case "10":
+		return 8
+	case "J":
+		return 9
+	case "Q":
+		return 10
+	case "K":
+		return 11
+	case "A":
+		return 12
+	}
+	return -1
+}
+
+// Rank returns the card's rank from 2 to 14 (ace), as in HandStrength.Values
+func (c PackedCard) Rank() int {
+	return int(c>>8&0xF) + 2
+}
+
+// Suit returns the card's suit symbol
+func (c PackedCard) Suit() string {
+	for i, suit := range packedSuits {
+		if c&(0x8000>>i) != 0 {
+			return suit
+		}
+	}
+	return ""
+}
+
+// Card unpacks the card
+func (c PackedCard) Card() *deck.Card {
+	return deck.NewCard(packedValues[c>>8&0xF], c.Suit())
+}
+
+// String returns the card's string representation, as deck.Card does
+func (c PackedCard) String() string {
+	return c.Card().String()
+}
@@ -0,0 +1,41 @@
+package holdem
+
+import (
+	"testing"
+
+	"github.com/genewoo/joker/internal/deck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackCard(t *testing.T) {
+	for _, card := range deck.NewDeck().Cards {
+		packed, err := PackCard(card)
+		assert.NoError(t, err)
+		assert.Equal(t, card, packed.Card())
+		assert.Equal(t, card.String(), packed.String())
+		assert.Equal(t, valueToRank[card.Value], packed.Rank())
+		assert.Equal(t, card.Suit, packed.Suit())
+	}
+
+	// The ace of spades in the Cactus Kev layout
+	packed, err := PackCard(deck.NewCard("A", "♠"))
+	assert.NoError(t, err)
+	assert.Equal(t, PackedCard(0x10001C29), packed)
+
+	tests := []struct {
+		name string
+		card *deck.Card
+	}{
+		{name: "Joker", card: deck.NewCard("Joker", "Red")},
+		{name: "Unknown value", card: deck.NewCard("1", "♠")},
+		{name: "Unknown suit", card: deck.NewCard("A", "x")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := PackCard(tt.card)
+			assert.Error(t, err)
+			_, err = PackCards([]*deck.Card{deck.NewCard("A", "♠"), tt.card})
+			assert.Error(t, err)
+		})
+	}
+}
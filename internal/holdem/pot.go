@@ -0,0 +1,160 @@
+package holdem
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/genewoo/joker/internal/deck"
+)
+
+// Pot is the main pot or a side pot, with the players who can win it
+type Pot struct {
+	Amount   int
+	Eligible []int // Indices of the players who have not folded and contributed to every level of the pot
+}
+
+// PotResult is the outcome of a pot at showdown
+type PotResult struct {
+	Pot
+	Winners []int // Indices of the players who share the pot
+}
+
+// Pots splits the chips in the pot into the main pot and any side pots, from the
+// players' contributions. A new side pot starts at each level a player is all-in
+// for; chips added with AddToPot go to the main pot.
+func (g *Game) Pots() []Pot {
+	// The contribution levels of the players still in the hand bound the pots
+	var levels []int
+	contributed := 0
+	for _, p := range g.Players {
+		contributed += p.Contributed
+		if !p.Folded && p.Contributed > 0 {
+			levels = append(levels, p.Contributed)
+		}
+	}
+	sort.Ints(levels)
+
+	var pots []Pot
+	previous := 0
+	for _, level := range levels {
+		if level == previous {
+			continue
+		}
+		pot := Pot{}
+		for i, p := range g.Players {
+			pot.Amount += min(p.Contributed, level) - min(p.Contributed, previous)
+			if !p.Folded && p.Contributed >= level {
+				pot.Eligible = append(pot.Eligible, i)
+			}
+		}
+		pots = append(pots, pot)
+		previous = level
+	}
+
+	// Folded players' chips above the top level and dead money stay in the pots
+	if len(pots) == 0 {
+		if total := g.Pot(); total > 0 {
+			pots = append(pots, Pot{Amount: total, Eligible: g.activeSeats()})
+		}
+		return pots
+	}
+	for _, p := range g.Players {
+		if p.Contributed > previous {
+			pots[len(pots)-1].Amount += p.Contributed - previous
+		}
+	}
+	pots[0].Amount += g.Pot() - contributed
+	return pots
+}
+
+func (g *Game) activeSeats() []int {
+	var seats []int
+	for i, p := range g.Players {
+		if !p.Folded {
+			seats = append(seats, i)
+		}
+	}
+	return seats
+}
+
+// Showdown ranks the hands of the players still in and pays out each pot to the best
+// eligible hands. A split pot's odd chips go one at a time to its winners in seat order,
+// starting left of the button. A player left alone after the others fold wins
+// without showing. The players' chips are credited with the payouts.
+// Returns an error if the betting is not over, the board is incomplete for a contested
+// pot, or the pots have already been paid out.
+func (g *Game) Showdown(ranker HandRanker) (*ShowdownResult, error) {
+	if g.betting != nil && g.street != Showdown {
+		return nil, fmt.Errorf("%w: the hand is still on the %s", ErrBettingNotComplete, g.street)
+	}
+	if g.settled {
+		return nil, fmt.Errorf("the pots have already been paid out")
+	}
+
+	active := g.activeSeats()
+	if len(active) > 1 && len(g.Community) != 5 {
+		return nil, fmt.Errorf("showdown requires exactly 5 community cards, got %d", len(g.Community))
+	}
+
+	handStrengths := make([]HandStrength, len(g.Players))
+	bestHands := make([][]*deck.Card, len(g.Players))
+	if len(active) > 1 {
+		for _, i := range active {
+			handStrengths[i], bestHands[i] = ranker.RankHand(g.gameType, g.Players[i].Cards, g.Community)
+		}
+	}
+
+	result := &ShowdownResult{
+		HandStrengths: handStrengths,
+		BestHands:     bestHands,
+		Winners:       g.potWinners(active, handStrengths),
+		Payouts:       make([]int, len(g.Players)),
+	}
+	for _, pot := range g.Pots() {
+		winners := g.potWinners(pot.Eligible, handStrengths)
+		result.Pots = append(result.Pots, PotResult{Pot: pot, Winners: winners})
+
+		share, odd := pot.Amount/len(winners), pot.Amount%len(winners)
+		for _, i := range g.seatOrder(winners) {
+			result.Payouts[i] += share
+			if odd > 0 {
+				result.Payouts[i]++
+				odd--
+			}
+		}
+	}
+
+	for i, payout := range result.Payouts {
+		g.Players[i].Chips += payout
+		g.Players[i].AllIn = false
+	}
+	g.settled = true
+	g.recordShowdown(result)
+	return result, nil
+}
+
+// potWinners returns the players with the best hand among the eligible players
+func (g *Game) potWinners(eligible []int, handStrengths []HandStrength) []int {
+	strengths := make([]HandStrength, len(eligible))
+	for i, seat := range eligible {
+		strengths[i] = handStrengths[seat]
+	}
+
+	var winners []int
+	for _, i := range FindWinners(strengths) {
+		winners = append(winners, eligible[i])
+	}
+	return winners
+}
+
+// seatOrder sorts the seats clockwise starting with the first seat left of the button
+func (g *Game) seatOrder(seats []int) []int {
+	ordered := append([]int{}, seats...)
+	distance := func(seat int) int {
+		return (seat - g.Button - 1 + len(g.Players)) % len(g.Players)
+	}
+	sort.Slice(ordered, func(a, b int) bool {
+		return distance(ordered[a]) < distance(ordered[b])
+	})
+	return ordered
+}
@@ -0,0 +1,156 @@
+package holdem
+
+import (
+	"testing"
+
+	"github.com/genewoo/joker/internal/deck"
+	"github.com/stretchr/testify/assert"
+)
+
+// dealOut deals the remaining streets of a hand whose betting is over, then replaces
+// the random cards with the given hole cards and board.
+func dealOut(t *testing.T, game *Game, holeCards [][]*deck.Card, board []*deck.Card) {
+	t.Helper()
+	for len(game.Community) < 5 {
+		if len(game.Community) == 0 {
+			assert.NoError(t, game.DealFlop())
+		} else {
+			assert.NoError(t, game.DealTurnOrRiver())
+		}
+		if game.ToAct() >= 0 {
+			t.Fatalf("player %d is still to act on the %s", game.ToAct(), game.Street())
+		}
+	}
+	for i, cards := range holeCards {
+		game.Players[i].Cards = cards
+	}
+	game.Community = board
+}
+
+func TestPots(t *testing.T) {
+	t.Run("Side pots for different all-in amounts", func(t *testing.T) {
+		game := newBettingGame(t, noLimit, 0, 20, 50, 100, 100)
+		act(t, game,
+			Action{Player: 3, Type: Fold},
+			Action{Player: 0, Type: AllIn},
+			Action{Player: 1, Type: AllIn},
+			Action{Player: 2, Type: Call},
+		)
+		assert.Equal(t, []Pot{
+			{Amount: 60, Eligible: []int{0, 1, 2}},
+			{Amount: 60, Eligible: []int{1, 2}},
+		}, game.Pots())
+	})
+
+	t.Run("Folded chips stay in the pot", func(t *testing.T) {
+		game := newBettingGame(t, noLimit, 0, 100, 100, 100)
+		act(t, game,
+			Action{Player: 0, Type: Raise, Amount: 10},
+			Action{Player: 1, Type: Fold},
+			Action{Player: 2, Type: Call},
+		)
+		assert.Equal(t, []Pot{{Amount: 21, Eligible: []int{0, 2}}}, game.Pots())
+	})
+
+	t.Run("Dead money goes to the main pot", func(t *testing.T) {
+		game := newBettingGame(t, noLimit, 0, 10, 100, 100)
+		game.AddToPot(5)
+		act(t, game,
+			Action{Player: 0, Type: AllIn},
+			Action{Player: 1, Type: Raise, Amount: 30},
+			Action{Player: 2, Type: Call},
+		)
+		assert.Equal(t, []Pot{
+			{Amount: 35, Eligible: []int{0, 1, 2}},
+			{Amount: 40, Eligible: []int{1, 2}},
+		}, game.Pots())
+	})
+}
+
+func TestShowdown(t *testing.T) {
+	t.Run("Short stack wins the main pot", func(t *testing.T) {
+		game := newBettingGame(t, noLimit, 0, 20, 50, 100)
+		act(t, game,
+			Action{Player: 0, Type: AllIn},
+			Action{Player: 1, Type: AllIn},
+			Action{Player: 2, Type: Call},
+		)
+		dealOut(t, game, [][]*deck.Card{
+			{deck.NewCard("A", "♠"), deck.NewCard("A", "♥")},
+			{deck.NewCard("K", "♠"), deck.NewCard("K", "♥")},
+			{deck.NewCard("Q", "♠"), deck.NewCard("Q", "♥")},
+		}, []*deck.Card{
+			deck.NewCard("2", "♣"), deck.NewCard("7", "♦"), deck.NewCard("9", "♥"),
+			deck.NewCard("J", "♠"), deck.NewCard("3", "♣"),
+		})
+
+		result, err := game.Showdown(NewSmartHandRanker())
+		assert.NoError(t, err)
+		assert.Equal(t, []int{0}, result.Winners)
+		assert.Len(t, result.Pots, 2)
+		assert.Equal(t, []int{0}, result.Pots[0].Winners)
+		assert.Equal(t, []int{1}, result.Pots[1].Winners)
+		assert.Equal(t, []int{60, 60, 0}, result.Payouts)
+		assert.Equal(t, []int{60, 60, 50}, []int{game.Players[0].Chips, game.Players[1].Chips, game.Players[2].Chips})
+		assert.False(t, game.Players[0].AllIn)
+
+		_, err = game.Showdown(NewSmartHandRanker())
+		assert.Error(t, err)
+	})
+
+	t.Run("Odd chip goes left of the button", func(t *testing.T) {
+		game := newBettingGame(t, noLimit, 0, 100, 100, 100)
+		game.AddToPot(1)
+		act(t, game,
+			Action{Player: 0, Type: Call},
+			Action{Player: 1, Type: Call},
+			Action{Player: 2, Type: Check},
+		)
+		for street := Flop; street <= River; street++ {
+			if street == Flop {
+				assert.NoError(t, game.DealFlop())
+			} else {
+				assert.NoError(t, game.DealTurnOrRiver())
+			}
+			act(t, game,
+				Action{Player: 1, Type: Check},
+				Action{Player: 2, Type: Check},
+				Action{Player: 0, Type: Check},
+			)
+		}
+		game.Players[0].Cards = []*deck.Card{deck.NewCard("A", "♠"), deck.NewCard("K", "♠")}
+		game.Players[1].Cards = []*deck.Card{deck.NewCard("A", "♥"), deck.NewCard("K", "♦")}
+		game.Players[2].Cards = []*deck.Card{deck.NewCard("7", "♣"), deck.NewCard("2", "♦")}
+		game.Community = []*deck.Card{
+			deck.NewCard("A", "♣"), deck.NewCard("K", "♥"), deck.NewCard("9", "♠"),
+			deck.NewCard("5", "♥"), deck.NewCard("3", "♣"),
+		}
+
+		result, err := game.Showdown(NewSmartHandRanker())
+		assert.NoError(t, err)
+		assert.Equal(t, []int{0, 1}, result.Winners)
+		assert.Equal(t, []int{3, 4, 0}, result.Payouts)
+	})
+
+	t.Run("Uncontested pot", func(t *testing.T) {
+		game := newBettingGame(t, noLimit, 0, 100, 100, 100)
+		act(t, game,
+			Action{Player: 0, Type: Raise, Amount: 6},
+			Action{Player: 1, Type: Fold},
+			Action{Player: 2, Type: Fold},
+		)
+		assert.Equal(t, Showdown, game.Street())
+
+		result, err := game.Showdown(NewSmartHandRanker())
+		assert.NoError(t, err)
+		assert.Equal(t, []int{0}, result.Winners)
+		assert.Equal(t, []int{5, 0, 0}, result.Payouts) // the uncalled raise is returned
+		assert.Equal(t, 103, game.Players[0].Chips)
+	})
+
+	t.Run("Betting not over", func(t *testing.T) {
+		game := newBettingGame(t, noLimit, 0, 100, 100)
+		_, err := game.Showdown(NewSmartHandRanker())
+		assert.ErrorIs(t, err, ErrBettingNotComplete)
+	})
+}
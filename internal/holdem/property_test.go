@@ -0,0 +1,111 @@
+package holdem
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/genewoo/joker/internal/deck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankersAgreeOnRandomHands(t *testing.T) {
+	d := deck.NewDeck()
+	d.Seed(1)
+	defaultRanker, smartRanker := NewDefaultHandRanker(), NewSmartHandRanker()
+
+	for i := 0; i < 2000; i++ {
+		d.Shuffle()
+		// copy the cards, so rankers appending to the hole cards cannot overwrite the board
+		hole, board := append([]*deck.Card{}, d.Cards[:2]...), append([]*deck.Card{}, d.Cards[2:7]...)
+		expected, _ := defaultRanker.RankHand(Texas, hole, board)
+		actual, _ := smartRanker.RankHand(Texas, hole, board)
+		assert.Equal(t, 0, expected.Compare(actual), "hole %v board %v", hole, board)
+	}
+}
+
+func TestSeededCalculatorIsReproducible(t *testing.T) {
+	players := [][]*deck.Card{
+		{deck.NewCard("A", "♠"), deck.NewCard("K", "♠")},
+		{deck.NewCard("Q", "♥"), deck.NewCard("Q", "♦")},
+		{deck.NewCard("7", "♣"), deck.NewCard("6", "♣")},
+	}
+	probabilities := func(seed int64) []float64 {
+		calc := NewWinningCalculator(players, 2000, NewSmartHandRanker())
+		calc.Seed(seed)
+		return calc.CalculateWinProbabilities()
+	}
+
+	assert.Equal(t, probabilities(3), probabilities(3))
+	assert.NotEqual(t, probabilities(3), probabilities(4))
+}
+
+func TestSeededGameIsReproducible(t *testing.T) {
+	deal := func(seed int64) []*deck.Card {
+		game := NewGame(Texas, 4)
+		game.Seed(seed)
+		assert.NoError(t, game.StartHand())
+		assert.NoError(t, game.StartHand()) // later hands continue the same source
+		assert.NoError(t, game.DealFlop())
+		var cards []*deck.Card
+		for _, p := range game.Players {
+			cards = append(cards, p.Cards...)
+		}
+		return append(cards, game.Community...)
+	}
+
+	assert.Equal(t, deal(5), deal(5))
+	assert.NotEqual(t, deal(5), deal(6))
+}
+
+func TestRandomHandsConserveChips(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	structures := []BettingStructure{NoLimit, PotLimit, FixedLimit}
+	actionTypes := []ActionType{Fold, Check, Call, Bet, Raise, AllIn}
+
+	for hand := 0; hand < 300; hand++ {
+		game := NewGame(Texas, 2+rng.Intn(5))
+		game.Seed(int64(hand))
+		game.Button = rng.Intn(len(game.Players))
+		total := 0
+		for i := range game.Players {
+			game.Players[i].Chips = 1 + rng.Intn(200)
+			total += game.Players[i].Chips
+		}
+		config := BettingConfig{Structure: structures[hand%len(structures)], SmallBlind: 1, BigBlind: 2, Ante: rng.Intn(2)}
+		assert.NoError(t, game.SetBetting(config))
+		if !assert.NoError(t, game.StartHand()) {
+			continue
+		}
+
+		for game.Street() != Showdown {
+			if game.ToAct() < 0 {
+				if game.Street() == Preflop {
+					assert.NoError(t, game.DealFlop())
+				} else {
+					assert.NoError(t, game.DealTurnOrRiver())
+				}
+				continue
+			}
+
+			// Try actions in a random order until one is legal; folding always is
+			minTo, maxTo := game.RaiseLimits()
+			for _, i := range rng.Perm(len(actionTypes)) {
+				amount := minTo
+				if maxTo > minTo {
+					amount += rng.Intn(maxTo - minTo + 1)
+				}
+				if game.Act(Action{Player: game.ToAct(), Type: actionTypes[i], Amount: amount}) == nil {
+					break
+				}
+			}
+		}
+
+		_, err := game.Showdown(NewSmartHandRanker())
+		assert.NoError(t, err)
+		chips := 0
+		for _, p := range game.Players {
+			chips += p.Chips
+		}
+		assert.Equal(t, total, chips, "hand %d", hand)
+	}
+}
@@ -0,0 +1,357 @@
+package holdem
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/genewoo/joker/internal/deck"
+)
+
+// Combo is a specific pair of hole cards in a range, together with the
+// weight (0-1] at which the range holds it.
+type Combo struct {
+	Cards  [2]*deck.Card
+	Weight float64
+}
+
+// String returns the combo's cards, e.g. "A♠K♠".
+func (c Combo) String() string {
+	return c.Cards[0].String() + c.Cards[1].String()
+}
+
+// Range is a set of weighted hole card combos, such as "QQ+, AKs, A2s-A5s".
+type Range struct {
+	Combos []Combo
+}
+
+// rangeRanks maps range notation rank characters to deck card values, from highest to lowest.
+var rangeRanks = []struct {
+	symbol byte
+	value  string
+}{
+	{'A', "A"}, {'K', "K"}, {'Q', "Q"}, {'J', "J"}, {'T', "10"},
+	{'9', "9"}, {'8', "8"}, {'7', "7"}, {'6', "6"}, {'5', "5"},
+	{'4', "4"}, {'3', "3"}, {'2', "2"},
+}
+
+var rangeSuits = []string{"♠", "♥", "♦", "♣"}
+
+// ParseRange parses a comma separated hand range in standard notation:
+//   - pairs: "QQ", "QQ+" (QQ and better), "22-55"
+//   - suited and offsuit hands: "AKs", "KTo", "AK" (both), "KTo+" (KTo, KJo, KQo), "A2s-A5s"
+//   - weights: any of the above followed by ":0.5" to hold its combos half of the time
+//
+// A combo listed more than once keeps the weight of its last occurrence.
+func ParseRange(notation string) (*Range, error) {
+	r := &Range{}
+	index := make(map[string]int)
+
+	for _, token := range strings.Split(notation, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+
+		combos, err := parseRangeToken(token)
+		if err != nil {
+			return nil, err
+		}
+		for _, combo := range combos {
+			key := combo.String()
+			if i, ok := index[key]; ok {
+				r.Combos[i] = combo
+				continue
+			}
+			index[key] = len(r.Combos)
+			r.Combos = append(r.Combos, combo)
+		}
+	}
+
+	if len(r.Combos) == 0 {
+		return nil, fmt.Errorf("range %q contains no hands", notation)
+	}
+	return r, nil
+}
+
+// Size returns the weighted number of combos in the range.
+func (r *Range) Size() float64 {
+	total := 0.0
+	for _, combo := range r.Combos {
+		total += combo.Weight
+	}
+	return total
+}
+
+// parseRangeToken expands a single range token, with an optional weight, into combos.
+func parseRangeToken(token string) ([]Combo, error) {
+	weight := 1.0
+	hands := token
+	if i := strings.Index(token, ":"); i >= 0 {
+		w, err := strconv.ParseFloat(token[i+1:], 64)
+		if err != nil || w <= 0 || w > 1 {
+			return nil, fmt.Errorf("invalid weight in %q: must be a number in (0, 1]", token)
+		}
+		weight = w
+		hands = token[:i]
+	}
+
+	var combos []Combo
+	var err error
+	switch {
+	case strings.HasSuffix(hands, "+"):
+		combos, err = parsePlusToken(strings.TrimSuffix(hands, "+"))
+	case strings.Contains(hands, "-"):
+		parts := strings.Split(hands, "-")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid range %q", token)
+		}
+		combos, err = parseDashToken(parts[0], parts[1])
+	default:
+		var h rangeHand
+		h, err = parseRangeHand(hands)
+		if err == nil {
+			combos = h.combos()
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid range %q: %v", token, err)
+	}
+
+	for i := range combos {
+		combos[i].Weight = weight
+	}
+	return combos, nil
+}
+
+// rangeHand is a starting hand class such as "AKs", indexed into rangeRanks.
+type rangeHand struct {
+	high, low int    // indices into rangeRanks; high <= low
+	suits     string // "s" for suited, "o" for offsuit, "" for both
+}
+
+// parseRangeHand parses a hand class like "QQ", "AKs", "KTo" or "AK".
+func parseRangeHand(s string) (rangeHand, error) {
+	if len(s) < 2 || len(s) > 3 {
+		return rangeHand{}, fmt.Errorf("hand %q must be two ranks and an optional s or o", s)
+	}
+
+	first, ok := rangeRankIndex(s[0])
+	if !ok {
+		return rangeHand{}, fmt.Errorf("unknown rank %q", s[0])
+	}
+	second, ok := rangeRankIndex(s[1])
+	if !ok {
+		return rangeHand{}, fmt.Errorf("unknown rank %q", s[1])
+	}
+	if first > second {
+		first, second = second, first
+	}
+
+	h := rangeHand{high: first, low: second}
+	if len(s) == 3 {
+		h.suits = strings.ToLower(s[2:])
+		if h.suits != "s" && h.suits != "o" {
+			return rangeHand{}, fmt.Errorf("unknown suitedness %q, expected s or o", s[2:])
+		}
+	}
+	if h.isPair() && h.suits != "" {
+		return rangeHand{}, fmt.Errorf("pair %q cannot be suited or offsuit", s)
+	}
+	return h, nil
+}
+
+func rangeRankIndex(symbol byte) (int, bool) {
+	if symbol >= 'a' && symbol <= 'z' {
+		symbol -= 'a' - 'A'
+	}
+	for i, rank := range rangeRanks {
+		if rank.symbol == symbol {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (h rangeHand) isPair() bool {
+	return h.high == h.low
+}
+
+// combos lists every specific combo of the hand class with weight 1.
+func (h rangeHand) combos() []Combo {
+	highValue := rangeRanks[h.high].value
+	lowValue := rangeRanks[h.low].value
+
+	var combos []Combo
+	for i, highSuit := range rangeSuits {
+		for j, lowSuit := range rangeSuits {
+			switch {
+			case h.isPair() && j <= i:
+				continue
+			case h.suits == "s" && i != j:
+				continue
+			case h.suits == "o" && i == j:
+				continue
+			}
+			combos = append(combos, Combo{
+				Cards:  [2]*deck.Card{deck.NewCard(highValue, highSuit), deck.NewCard(lowValue, lowSuit)},
+				Weight: 1,
+			})
+		}
+	}
+	return combos
+}
+
+// parsePlusToken expands "QQ+" to QQ and better pairs, and "KTo+" to KTo, KJo and KQo.
+func parsePlusToken(s string) ([]Combo, error) {
+	h, err := parseRangeHand(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var combos []Combo
+	if h.isPair() {
+		for i := h.high; i >= 0; i-- {
+			combos = append(combos, rangeHand{high: i, low: i}.combos()...)
+		}
+		return combos, nil
+	}
+	for i := h.low; i > h.high; i-- {
+		combos = append(combos, rangeHand{high: h.high, low: i, suits: h.suits}.combos()...)
+	}
+	return combos, nil
+}
+
+// parseDashToken expands "22-55" to the pairs in between, and "A2s-A5s" to the
+// hands sharing the high card with kickers in between.
+func parseDashToken(from, to string) ([]Combo, error) {
+	start, err := parseRangeHand(from)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseRangeHand(to)
+	if err != nil {
+		return nil, err
+	}
+	if start.isPair() != end.isPair() || start.suits != end.suits || (!start.isPair() && start.high != end.high) {
+		return nil, fmt.Errorf("%s and %s do not bound a range", from, to)
+	}
+
+	// Order bounds by kicker (or pair) rank index, from strongest to weakest
+	first, last := start.low, end.low
+	if first > last {
+		first, last = last, first
+	}
+
+	var combos []Combo
+	for i := first; i <= last; i++ {
+		h := rangeHand{high: start.high, low: i, suits: start.suits}
+		if start.isPair() {
+			h.high = i
+		}
+		if !h.isPair() && h.high == h.low {
+			continue
+		}
+		combos = append(combos, h.combos()...)
+	}
+	return combos, nil
+}
+
+// CalculateRangeEquities calculates the probability of each range winning against the others
+// by running Monte Carlo simulations. Each simulation deals every range a combo, chosen by
+// weight among those that conflict with neither the community cards nor the other ranges'
+// combos, and completes the board at random.
+// The calculator's own players are ignored; its simulations, ranker, game type and
+// community cards are used. The result has the same layout as CalculateWinProbabilities.
+// Returns an error if a range has no combo left after removing conflicts.
+func (wc *WinningCalculator) CalculateRangeEquities(ranges []*Range) ([]float64, error) {
+	if len(ranges) == 0 {
+		return nil, nil
+	}
+	if wc.gameType == Omaha {
+		return nil, fmt.Errorf("ranges of two-card combos cannot be used for %s", wc.gameType)
+	}
+
+	// Drop combos that use community cards or cards missing from the game's deck
+	available := make(map[string]bool)
+	for _, card := range newGameDeck(wc.gameType).Cards {
+		available[card.String()] = true
+	}
+	for _, card := range wc.communityCards {
+		delete(available, card.String())
+	}
+
+	candidates := make([][]Combo, len(ranges))
+	cumulative := make([][]float64, len(ranges))
+	for i, r := range ranges {
+		total := 0.0
+		for _, combo := range r.Combos {
+			if !available[combo.Cards[0].String()] || !available[combo.Cards[1].String()] {
+				continue
+			}
+			total += combo.Weight
+			candidates[i] = append(candidates[i], combo)
+			cumulative[i] = append(cumulative[i], total)
+		}
+		if len(candidates[i]) == 0 {
+			return nil, fmt.Errorf("range %d has no hands left after removing the community cards", i+1)
+		}
+	}
+
+	results := make([]float64, len(ranges))
+	var tieCount float64
+	players := make([][]*deck.Card, len(ranges))
+	calc := &WinningCalculator{players: players, ranker: wc.ranker, gameType: wc.gameType}
+	remainingCards := 5 - len(wc.communityCards)
+
+	// Reject whole deals with conflicting combos so that no range is favoured
+	const maxAttempts = 1000
+	for sim := 0; sim < wc.simulations; sim++ {
+		dealt := false
+		for attempt := 0; attempt < maxAttempts && !dealt; attempt++ {
+			used := make(map[string]bool, 2*len(ranges))
+			dealt = true
+			for i := range ranges {
+				combo := candidates[i][wc.pickCombo(cumulative[i])]
+				first, second := combo.Cards[0].String(), combo.Cards[1].String()
+				if used[first] || used[second] {
+					dealt = false
+					break
+				}
+				used[first], used[second] = true, true
+				players[i] = combo.Cards[:]
+			}
+		}
+		if !dealt {
+			return nil, fmt.Errorf("could not deal non-conflicting hands from the ranges")
+		}
+
+		// Complete the board from the cards left over
+		var masks []string
+		for _, hand := range players {
+			masks = append(masks, hand[0].String(), hand[1].String())
+		}
+		for _, card := range wc.communityCards {
+			masks = append(masks, card.String())
+		}
+		d := newGameDeck(wc.gameType, masks...)
+		board := append([]*deck.Card{}, wc.communityCards...)
+		for i := 0; i < remainingCards; i++ {
+			j := i + wc.rng.Intn(len(d.Cards)-i)
+			d.Cards[i], d.Cards[j] = d.Cards[j], d.Cards[i]
+			board = append(board, d.Cards[i])
+		}
+
+		tieCount += calc.scoreBoard(board, results)
+	}
+
+	return probabilities(results, tieCount, wc.simulations), nil
+}
+
+// pickCombo chooses a combo index at random in proportion to the combo weights,
+// given as a running total.
+func (wc *WinningCalculator) pickCombo(cumulative []float64) int {
+	target := wc.rng.Float64() * cumulative[len(cumulative)-1]
+	return sort.SearchFloat64s(cumulative, target)
+}
@@ -0,0 +1,127 @@
+package holdem
+
+import (
+	"testing"
+
+	"github.com/genewoo/joker/internal/deck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		notation      string
+		expectedCount int
+		expectedSize  float64
+		expectError   bool
+	}{
+		{name: "Pair", notation: "QQ", expectedCount: 6, expectedSize: 6},
+		{name: "Pair plus", notation: "QQ+", expectedCount: 18, expectedSize: 18},
+		{name: "Pair span", notation: "22-55", expectedCount: 24, expectedSize: 24},
+		{name: "Suited", notation: "AKs", expectedCount: 4, expectedSize: 4},
+		{name: "Offsuit", notation: "KTo", expectedCount: 12, expectedSize: 12},
+		{name: "Suited and offsuit", notation: "AK", expectedCount: 16, expectedSize: 16},
+		{name: "Offsuit plus", notation: "KTo+", expectedCount: 36, expectedSize: 36},
+		{name: "Suited span", notation: "A2s-A5s", expectedCount: 16, expectedSize: 16},
+		{name: "Reversed span", notation: "A5s-A2s", expectedCount: 16, expectedSize: 16},
+		{name: "Lowercase ranks", notation: "akS", expectedCount: 4, expectedSize: 4},
+		{name: "Weighted", notation: "AA:0.5", expectedCount: 6, expectedSize: 3},
+		{name: "Combined", notation: "QQ+, AKs, A2s-A5s, KTo+, 76s", expectedCount: 78, expectedSize: 78},
+		{name: "Overlapping tokens keep last weight", notation: "QQ+, AA:0.5", expectedCount: 18, expectedSize: 15},
+		{name: "Unknown rank", notation: "AX", expectError: true},
+		{name: "Single rank", notation: "A", expectError: true},
+		{name: "Suited pair", notation: "AAs", expectError: true},
+		{name: "Unknown suitedness", notation: "AKx", expectError: true},
+		{name: "Mismatched span", notation: "QQ-AKs", expectError: true},
+		{name: "Span with different high cards", notation: "A2s-K5s", expectError: true},
+		{name: "Weight too large", notation: "AA:1.5", expectError: true},
+		{name: "Weight not a number", notation: "AA:half", expectError: true},
+		{name: "Empty", notation: " , ", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRange(tt.notation)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, r)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, r.Combos, tt.expectedCount)
+			assert.InDelta(t, tt.expectedSize, r.Size(), 1e-9)
+		})
+	}
+}
+
+func TestParseRangeCombos(t *testing.T) {
+	t.Parallel()
+
+	r, err := ParseRange("KTo+")
+	assert.NoError(t, err)
+	for _, combo := range r.Combos {
+		assert.Equal(t, "K", combo.Cards[0].Value)
+		assert.Contains(t, []string{"10", "J", "Q"}, combo.Cards[1].Value)
+		assert.NotEqual(t, combo.Cards[0].Suit, combo.Cards[1].Suit)
+	}
+
+	r, err = ParseRange("76s")
+	assert.NoError(t, err)
+	for _, combo := range r.Combos {
+		assert.Equal(t, combo.Cards[0].Suit, combo.Cards[1].Suit)
+	}
+}
+
+func TestCalculateRangeEquities(t *testing.T) {
+	t.Parallel()
+
+	mustParse := func(notation string) *Range {
+		r, err := ParseRange(notation)
+		assert.NoError(t, err)
+		return r
+	}
+
+	t.Run("Aces against kings", func(t *testing.T) {
+		calc := NewWinningCalculator(nil, 5000, NewSmartHandRanker())
+		probs, err := calc.CalculateRangeEquities([]*Range{mustParse("AA"), mustParse("KK")})
+		assert.NoError(t, err)
+		assert.Len(t, probs, 3)
+		assert.InDelta(t, 0.82, probs[0], 0.04)
+		assert.InDelta(t, 0.18, probs[1], 0.04)
+	})
+
+	t.Run("Identical ranges split", func(t *testing.T) {
+		calc := NewWinningCalculator(nil, 2000, NewSmartHandRanker())
+		probs, err := calc.CalculateRangeEquities([]*Range{mustParse("AKs"), mustParse("AKs")})
+		assert.NoError(t, err)
+		assert.InDelta(t, probs[0], probs[1], 0.05)
+		assert.Greater(t, probs[2], 0.5)
+	})
+
+	t.Run("Board removes conflicting combos", func(t *testing.T) {
+		board := []*deck.Card{deck.NewCard("A", "♠"), deck.NewCard("A", "♥"), deck.NewCard("A", "♦")}
+		calc := NewWinningCalculator(nil, 100, NewSmartHandRanker(), board...)
+		_, err := calc.CalculateRangeEquities([]*Range{mustParse("AA"), mustParse("KK")})
+		assert.Error(t, err)
+
+		probs, err := calc.CalculateRangeEquities([]*Range{mustParse("AK"), mustParse("KK")})
+		assert.NoError(t, err)
+		assert.Equal(t, 1.0, probs[0])
+	})
+
+	t.Run("Ranges that always conflict", func(t *testing.T) {
+		board := []*deck.Card{deck.NewCard("A", "♠"), deck.NewCard("A", "♥")}
+		calc := NewWinningCalculator(nil, 100, NewSmartHandRanker(), board...)
+		_, err := calc.CalculateRangeEquities([]*Range{mustParse("AA"), mustParse("AA")})
+		assert.Error(t, err)
+	})
+
+	t.Run("Omaha is rejected", func(t *testing.T) {
+		calc := NewWinningCalculator(nil, 100, NewSmartHandRanker())
+		calc.SetGameType(Omaha)
+		_, err := calc.CalculateRangeEquities([]*Range{mustParse("AA"), mustParse("KK")})
+		assert.Error(t, err)
+	})
+}
@@ -43,11 +43,23 @@ const (
 type HandStrength struct {
 	Rank   HandRank
 	Values []int // Card values in descending order of importance
+
+	// order ranks the hand categories of the game variant the hand was
+	// evaluated under; nil means the standard Hold'em order of HandRank.
+	order []int
+}
+
+// ShortDeckRules configures the optional ranking variations of short-deck Hold'em.
+// A flush always beats a full house and A-6-7-8-9 is always the lowest straight.
+type ShortDeckRules struct {
+	// TripsBeatStraight ranks three of a kind above a straight
+	TripsBeatStraight bool
 }
 
 // HandRanker defines the interface for ranking poker hands.
 type HandRanker interface {
-	// RankHand evaluates the best 5-card hand from a player's 2 cards and 5 community cards
+	// RankHand evaluates the best 5-card hand from a player's hole cards and 5 community cards
+	// following the rules of the given game type
 	RankHand(gameType GameType, playerCards []*deck.Card, communityCards []*deck.Card) (HandStrength, []*deck.Card)
 }
 
@@ -58,12 +70,14 @@ type HandRanker interface {
 // by evaluating every possible 5-card combination from the 7 available cards.
 type DefaultHandRanker struct {
 	organizer deck.Organizer
+	shortDeck ShortDeckRules
 }
 
 // SmartHandRanker implements HandRanker using a more efficient algorithm
 // that avoids evaluating unnecessary combinations by analyzing card patterns.
 type SmartHandRanker struct {
 	organizer deck.Organizer
+	shortDeck ShortDeckRules
 }
 
 // Factory Functions
@@ -102,10 +116,10 @@ func NewHandStrength() HandStrength {
 //	 1 if this hand is stronger than other
 func (h HandStrength) Compare(other HandStrength) int {
 	// First compare ranks
-	if h.Rank < other.Rank {
+	if h.category() < other.category() {
 		return -1
 	}
-	if h.Rank > other.Rank {
+	if h.category() > other.category() {
 		return 1
 	}
 
@@ -123,6 +137,14 @@ func (h HandStrength) Compare(other HandStrength) int {
 	return 0
 }
 
+// category returns the position of the hand's rank in its game variant's order.
+func (h HandStrength) category() int {
+	if h.order == nil {
+		return int(h.Rank)
+	}
+	return h.order[h.Rank]
+}
+
 // HandRank Methods
 // ===============
 
@@ -146,42 +168,84 @@ func (hr HandRank) String() string {
 // DefaultHandRanker Methods
 // ========================
 
-// RankHand evaluates the best 5-card hand from a player's 2 hole cards
+// RankHand evaluates the best 5-card hand from a player's hole cards
 // and 5 community cards using the traditional all-combinations approach.
+// Texas and Short hands use 2 hole cards; Omaha hands use exactly two of
+// the 4 hole cards and exactly three community cards.
 // Returns the hand strength and the best 5 cards that form the hand.
 func (r *DefaultHandRanker) RankHand(gameType GameType, playerCards []*deck.Card, communityCards []*deck.Card) (HandStrength, []*deck.Card) {
+	if gameType == Omaha {
+		return rankOmaha(playerCards, communityCards, func(cards []*deck.Card) (HandStrength, []*deck.Card) {
+			return r.rankCards(cards, nil)
+		})
+	}
+
 	if len(playerCards) != 2 || len(communityCards) != 5 {
-		strength := NewHandStrength()
-		strength.Rank = InvalidHand
-		return strength, nil
+		return invalidHand()
 	}
 
-	// Combine and sort all cards
-	allCards := append(playerCards, communityCards...)
-	r.organizer.Sort(allCards)
+	var shortDeck *ShortDeckRules
+	if gameType == Short {
+		shortDeck = &r.shortDeck
+	}
+	return r.rankCards(append(playerCards, communityCards...), shortDeck)
+}
+
+// SetShortDeckRules sets the ranking variations applied to Short game hands.
+func (r *DefaultHandRanker) SetShortDeckRules(rules ShortDeckRules) {
+	r.shortDeck = rules
+}
 
-	return evaluateAllCombinations(allCards)
+// rankCards sorts the cards and evaluates every 5-card combination among them.
+// shortDeck selects short-deck ranking when not nil.
+func (r *DefaultHandRanker) rankCards(allCards []*deck.Card, shortDeck *ShortDeckRules) (HandStrength, []*deck.Card) {
+	r.organizer.Sort(allCards)
+	return evaluateAllCombinations(allCards, shortDeck)
 }
 
 // SmartHandRanker Methods
 // ======================
 
-// RankHand evaluates the best 5-card hand from a player's 2 hole cards
+// RankHand evaluates the best 5-card hand from a player's hole cards
 // and 5 community cards using an optimized pattern-matching algorithm.
+// Texas and Short hands use 2 hole cards; Omaha hands use exactly two of
+// the 4 hole cards and exactly three community cards.
 // Returns the hand strength and the best 5 cards that form the hand.
 func (r *SmartHandRanker) RankHand(gameType GameType, playerCards []*deck.Card, communityCards []*deck.Card) (HandStrength, []*deck.Card) {
+	if gameType == Omaha {
+		return rankOmaha(playerCards, communityCards, func(cards []*deck.Card) (HandStrength, []*deck.Card) {
+			return r.rankCards(cards, nil)
+		})
+	}
+
 	if len(playerCards) != 2 || len(communityCards) != 5 {
-		strength := NewHandStrength()
-		strength.Rank = InvalidHand
-		return strength, nil
+		return invalidHand()
+	}
+
+	var shortDeck *ShortDeckRules
+	if gameType == Short {
+		shortDeck = &r.shortDeck
 	}
+	strength, bestHand := r.rankCards(append(playerCards, communityCards...), shortDeck)
+	strength.order = shortDeck.order()
+	return strength, bestHand
+}
 
-	// Combine and sort all cards
-	allCards := append(playerCards, communityCards...)
+// SetShortDeckRules sets the ranking variations applied to Short game hands.
+func (r *SmartHandRanker) SetShortDeckRules(rules ShortDeckRules) {
+	r.shortDeck = rules
+}
+
+// rankCards finds the best 5-card hand among five or more cards.
+// shortDeck selects short-deck ranking when not nil.
+func (r *SmartHandRanker) rankCards(allCards []*deck.Card, shortDeck *ShortDeckRules) (HandStrength, []*deck.Card) {
 	r.organizer.Sort(allCards)
 
 	// Build analysis maps
 	valueCount, _, rankBits, suitedCards := buildHandAnalysis(allCards)
+	if shortDeck != nil {
+		rankBits = shortDeckRankBits(rankBits)
+	}
 
 	strength := NewHandStrength()
 	var bestHand []*deck.Card
@@ -205,6 +269,9 @@ func (r *SmartHandRanker) RankHand(gameType GameType, playerCards []*deck.Card,
 				}
 			}
 		}
+		if shortDeck != nil {
+			flushRankBits = shortDeckRankBits(flushRankBits)
+		}
 
 		straightFound, straightLowestRank := findStraight(flushRankBits)
 		if straightFound {
@@ -230,6 +297,11 @@ func (r *SmartHandRanker) RankHand(gameType GameType, playerCards []*deck.Card,
 		return strength, bestHand
 	}
 
+	// Short deck ranks a flush above a full house
+	if shortDeck != nil && flushCards != nil {
+		return flushStrength(flushCards)
+	}
+
 	// Check for full house
 	if threeValue, pairValue, found := findFullHouse(valueCount); found {
 		strength.Rank = FullHouse
@@ -240,13 +312,12 @@ func (r *SmartHandRanker) RankHand(gameType GameType, playerCards []*deck.Card,
 
 	// Handle flush
 	if flushCards != nil {
-		strength.Rank = Flush
-		strength.Values = make([]int, 5)
-		for i, card := range flushCards {
-			strength.Values[i] = valueToRank[card.Value]
-		}
-		bestHand = flushCards
-		return strength, bestHand
+		return flushStrength(flushCards)
+	}
+
+	// Short deck may rank three of a kind above a straight
+	if shortDeck != nil && shortDeck.TripsBeatStraight && hasCount(valueCount, 3) {
+		return evaluateRemainingCombinations(allCards, valueCount, valueToRank)
 	}
 
 	// Handle straight
@@ -261,9 +332,99 @@ func (r *SmartHandRanker) RankHand(gameType GameType, playerCards []*deck.Card,
 	return evaluateRemainingCombinations(allCards, valueCount, valueToRank)
 }
 
+// flushStrength builds the strength of a flush from its 5 cards in descending order.
+func flushStrength(flushCards []*deck.Card) (HandStrength, []*deck.Card) {
+	strength := NewHandStrength()
+	strength.Rank = Flush
+	strength.Values = make([]int, 5)
+	for i, card := range flushCards {
+		strength.Values[i] = valueToRank[card.Value]
+	}
+	return strength, flushCards
+}
+
 // Helper Functions
 // ===============
 
+// Hand category orders for short-deck Hold'em, indexed by HandRank. A flush
+// ranks above a full house, and optionally three of a kind above a straight.
+var (
+	shortDeckOrder      = []int{0, 1, 2, 3, 4, 5, 7, 6, 8, 9, 10}
+	shortDeckTripsOrder = []int{0, 1, 2, 3, 5, 4, 7, 6, 8, 9, 10}
+)
+
+// order returns the hand category order for the rules; nil rules mean standard Hold'em.
+func (rules *ShortDeckRules) order() []int {
+	if rules == nil {
+		return nil
+	}
+	if rules.TripsBeatStraight {
+		return shortDeckTripsOrder
+	}
+	return shortDeckOrder
+}
+
+// shortDeckRankBits moves the low Ace from below the 2 to below the 6, so that
+// A-6-7-8-9 is found as the lowest straight of a deck without 2-5.
+func shortDeckRankBits(rankBits int) int {
+	if rankBits&(1<<13) != 0 {
+		rankBits |= 1 << 4
+	}
+	return rankBits &^ (1 << 0)
+}
+
+// hasCount reports whether any value appears exactly count times.
+func hasCount(valueCount map[string]int, count int) bool {
+	for _, c := range valueCount {
+		if c == count {
+			return true
+		}
+	}
+	return false
+}
+
+// invalidHand returns the result reported for hands that cannot be ranked.
+func invalidHand() (HandStrength, []*deck.Card) {
+	strength := NewHandStrength()
+	strength.Rank = InvalidHand
+	return strength, nil
+}
+
+// rankOmaha evaluates an Omaha hand, which must be made from exactly two of the
+// 4 hole cards and exactly three of the 5 community cards. rank is used to
+// evaluate each of the 60 candidate 5-card hands.
+func rankOmaha(playerCards, communityCards []*deck.Card, rank func([]*deck.Card) (HandStrength, []*deck.Card)) (HandStrength, []*deck.Card) {
+	if len(playerCards) != 4 || len(communityCards) != 5 {
+		return invalidHand()
+	}
+
+	var bestStrength HandStrength
+	var bestHand []*deck.Card
+	for first := 0; first < len(playerCards); first++ {
+		for second := first + 1; second < len(playerCards); second++ {
+			for a := 0; a < len(communityCards); a++ {
+				for b := a + 1; b < len(communityCards); b++ {
+					for c := b + 1; c < len(communityCards); c++ {
+						cards := []*deck.Card{
+							playerCards[first],
+							playerCards[second],
+							communityCards[a],
+							communityCards[b],
+							communityCards[c],
+						}
+						strength, hand := rank(cards)
+						if bestHand == nil || compareHands(strength, bestStrength) == 1 {
+							bestStrength = strength
+							bestHand = hand
+						}
+					}
+				}
+			}
+		}
+	}
+	return bestStrength, bestHand
+}
+
 // Common value to rank mapping used across functions
 var valueToRank = map[string]int{
 	"2": 2, "3": 3, "4": 4, "5": 5, "6": 6, "7": 7, "8": 8, "9": 9,
@@ -394,8 +555,9 @@ func collectStraightCards(cards []*deck.Card, lowestRank int, requiredSuit strin
 			}
 		}
 		if !found {
-			// Special case for Ace when it's used as 1 in A-5 straight
-			if i == 1 {
+			// Special case for Ace when it's used low: as 1 in A-5 straight,
+			// or below the 6 in short deck A-9 straight
+			if i == lowestRank {
 				for _, card := range cards {
 					if card.Value == "A" && (requiredSuit == "" || card.Suit == requiredSuit) {
 						result = append(result, card)
@@ -518,7 +680,7 @@ func compareHands(hand1, hand2 HandStrength) int {
 	return hand1.Compare(hand2)
 }
 
-func evaluateAllCombinations(cards []*deck.Card) (HandStrength, []*deck.Card) {
+func evaluateAllCombinations(cards []*deck.Card, shortDeck *ShortDeckRules) (HandStrength, []*deck.Card) {
 	var bestStrength HandStrength
 	var bestHand []*deck.Card
 
@@ -536,7 +698,7 @@ func evaluateAllCombinations(cards []*deck.Card) (HandStrength, []*deck.Card) {
 							cards[fourth],
 							cards[fifth],
 						}
-						currentStrength := evaluateHand(currentHand)
+						currentStrength := evaluateHand(currentHand, shortDeck)
 						if bestHand == nil || compareHands(currentStrength, bestStrength) == 1 {
 							bestStrength = currentStrength
 							bestHand = currentHand
@@ -549,9 +711,12 @@ func evaluateAllCombinations(cards []*deck.Card) (HandStrength, []*deck.Card) {
 	return bestStrength, bestHand
 }
 
-func evaluateHand(hand []*deck.Card) HandStrength {
+func evaluateHand(hand []*deck.Card, shortDeck *ShortDeckRules) HandStrength {
 	// Build analysis maps
 	valueCount, suitCount, rankBits, _ := buildHandAnalysis(hand)
+	if shortDeck != nil {
+		rankBits = shortDeckRankBits(rankBits)
+	}
 
 	// Check for flush (all cards same suit)
 	flush := false
@@ -567,6 +732,7 @@ func evaluateHand(hand []*deck.Card) HandStrength {
 
 	// Create HandStrength with basic rank
 	strength := NewHandStrength()
+	strength.order = shortDeck.order()
 
 	// Populate values with card ranks
 	for _, card := range hand {
@@ -524,6 +524,311 @@ func TestSmartRankHand(t *testing.T) {
 	}
 }
 
+var omahaTestCases = []struct {
+	name           string
+	playerCards    []*deck.Card
+	communityCards []*deck.Card
+	expectedRank   HandStrength
+}{
+	{
+		name: "Board flush needs two suited hole cards",
+		playerCards: []*deck.Card{
+			{Value: "A", Suit: "♠"},
+			{Value: "K", Suit: "♥"},
+			{Value: "Q", Suit: "♦"},
+			{Value: "J", Suit: "♣"},
+		},
+		communityCards: []*deck.Card{
+			{Value: "2", Suit: "♠"},
+			{Value: "5", Suit: "♠"},
+			{Value: "7", Suit: "♠"},
+			{Value: "9", Suit: "♠"},
+			{Value: "3", Suit: "♥"},
+		},
+		expectedRank: HandStrength{
+			Rank:   HighCard,
+			Values: []int{14, 13, 9, 7, 5},
+		},
+	},
+	{
+		name: "Nut flush with two suited hole cards",
+		playerCards: []*deck.Card{
+			{Value: "A", Suit: "♠"},
+			{Value: "K", Suit: "♠"},
+			{Value: "Q", Suit: "♦"},
+			{Value: "J", Suit: "♣"},
+		},
+		communityCards: []*deck.Card{
+			{Value: "2", Suit: "♠"},
+			{Value: "5", Suit: "♠"},
+			{Value: "7", Suit: "♠"},
+			{Value: "9", Suit: "♠"},
+			{Value: "3", Suit: "♥"},
+		},
+		expectedRank: HandStrength{
+			Rank:   Flush,
+			Values: []int{14, 13, 9, 7, 5},
+		},
+	},
+	{
+		name: "Four of a kind in hand only counts as a pair",
+		playerCards: []*deck.Card{
+			{Value: "8", Suit: "♠"},
+			{Value: "8", Suit: "♥"},
+			{Value: "8", Suit: "♦"},
+			{Value: "8", Suit: "♣"},
+		},
+		communityCards: []*deck.Card{
+			{Value: "2", Suit: "♠"},
+			{Value: "4", Suit: "♥"},
+			{Value: "6", Suit: "♦"},
+			{Value: "10", Suit: "♣"},
+			{Value: "Q", Suit: "♥"},
+		},
+		expectedRank: HandStrength{
+			Rank:   OnePair,
+			Values: []int{8, 12, 10, 6},
+		},
+	},
+	{
+		name: "Board trips need a pocket pair for a full house",
+		playerCards: []*deck.Card{
+			{Value: "K", Suit: "♠"},
+			{Value: "K", Suit: "♥"},
+			{Value: "2", Suit: "♦"},
+			{Value: "3", Suit: "♣"},
+		},
+		communityCards: []*deck.Card{
+			{Value: "9", Suit: "♠"},
+			{Value: "9", Suit: "♥"},
+			{Value: "9", Suit: "♦"},
+			{Value: "5", Suit: "♣"},
+			{Value: "J", Suit: "♥"},
+		},
+		expectedRank: HandStrength{
+			Rank:   FullHouse,
+			Values: []int{9, 13},
+		},
+	},
+	{
+		name: "One hole card cannot complete a straight",
+		playerCards: []*deck.Card{
+			{Value: "10", Suit: "♠"},
+			{Value: "2", Suit: "♥"},
+			{Value: "2", Suit: "♦"},
+			{Value: "3", Suit: "♣"},
+		},
+		communityCards: []*deck.Card{
+			{Value: "J", Suit: "♠"},
+			{Value: "Q", Suit: "♥"},
+			{Value: "K", Suit: "♦"},
+			{Value: "A", Suit: "♣"},
+			{Value: "7", Suit: "♥"},
+		},
+		expectedRank: HandStrength{
+			Rank:   OnePair,
+			Values: []int{2, 14, 13, 12},
+		},
+	},
+	{
+		name: "Invalid Hand - two hole cards",
+		playerCards: []*deck.Card{
+			{Value: "A", Suit: "♠"},
+			{Value: "K", Suit: "♠"},
+		},
+		communityCards: []*deck.Card{
+			{Value: "Q", Suit: "♠"},
+			{Value: "J", Suit: "♠"},
+			{Value: "10", Suit: "♠"},
+			{Value: "2", Suit: "♥"},
+			{Value: "3", Suit: "♦"},
+		},
+		expectedRank: HandStrength{Rank: InvalidHand},
+	},
+	{
+		name: "Invalid Hand - four community cards",
+		playerCards: []*deck.Card{
+			{Value: "A", Suit: "♠"},
+			{Value: "K", Suit: "♠"},
+			{Value: "Q", Suit: "♦"},
+			{Value: "J", Suit: "♣"},
+		},
+		communityCards: []*deck.Card{
+			{Value: "Q", Suit: "♠"},
+			{Value: "J", Suit: "♠"},
+			{Value: "10", Suit: "♠"},
+			{Value: "2", Suit: "♥"},
+		},
+		expectedRank: HandStrength{Rank: InvalidHand},
+	},
+}
+
+func TestOmahaRankHand(t *testing.T) {
+	t.Parallel()
+	rankers := map[string]HandRanker{
+		"Default": NewDefaultHandRanker(),
+		"Smart":   NewSmartHandRanker(),
+		"Lookup":  NewLookupHandRanker(),
+	}
+	for rankerName, ranker := range rankers {
+		for _, tt := range omahaTestCases {
+			t.Run(rankerName+"/"+tt.name, func(t *testing.T) {
+				rank, cards := ranker.RankHand(Omaha, tt.playerCards, tt.communityCards)
+				assert.Equal(t, tt.expectedRank.Rank, rank.Rank)
+				if tt.expectedRank.Rank == InvalidHand {
+					assert.Nil(t, cards)
+					return
+				}
+				assert.Equal(t, tt.expectedRank.Values, rank.Values)
+				assert.Len(t, cards, 5)
+
+				// The best hand must use exactly two hole cards
+				holeUsed := 0
+				for _, card := range cards {
+					for _, hole := range tt.playerCards {
+						if card == hole {
+							holeUsed++
+						}
+					}
+				}
+				assert.Equal(t, 2, holeUsed)
+			})
+		}
+	}
+}
+
+func TestShortDeckRankHand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		playerCards       []*deck.Card
+		communityCards    []*deck.Card
+		tripsBeatStraight bool
+		expectedRank      HandStrength
+	}{
+		{
+			name: "A-6-7-8-9 is the lowest straight",
+			playerCards: []*deck.Card{
+				{Value: "A", Suit: "♠"},
+				{Value: "6", Suit: "♥"},
+			},
+			communityCards: []*deck.Card{
+				{Value: "7", Suit: "♦"},
+				{Value: "8", Suit: "♣"},
+				{Value: "9", Suit: "♠"},
+				{Value: "K", Suit: "♥"},
+				{Value: "K", Suit: "♦"},
+			},
+			expectedRank: HandStrength{
+				Rank:   Straight,
+				Values: []int{9},
+			},
+		},
+		{
+			name: "A-6-7-8-9 straight flush",
+			playerCards: []*deck.Card{
+				{Value: "A", Suit: "♣"},
+				{Value: "6", Suit: "♣"},
+			},
+			communityCards: []*deck.Card{
+				{Value: "7", Suit: "♣"},
+				{Value: "8", Suit: "♣"},
+				{Value: "9", Suit: "♣"},
+				{Value: "K", Suit: "♥"},
+				{Value: "K", Suit: "♦"},
+			},
+			expectedRank: HandStrength{
+				Rank:   StraightFlush,
+				Values: []int{9},
+			},
+		},
+		{
+			name: "Straight beats trips by default",
+			playerCards: []*deck.Card{
+				{Value: "10", Suit: "♠"},
+				{Value: "10", Suit: "♥"},
+			},
+			communityCards: []*deck.Card{
+				{Value: "10", Suit: "♦"},
+				{Value: "J", Suit: "♣"},
+				{Value: "Q", Suit: "♠"},
+				{Value: "K", Suit: "♥"},
+				{Value: "9", Suit: "♦"},
+			},
+			expectedRank: HandStrength{
+				Rank:   Straight,
+				Values: []int{13},
+			},
+		},
+		{
+			name: "Trips beat straight when enabled",
+			playerCards: []*deck.Card{
+				{Value: "10", Suit: "♠"},
+				{Value: "10", Suit: "♥"},
+			},
+			communityCards: []*deck.Card{
+				{Value: "10", Suit: "♦"},
+				{Value: "J", Suit: "♣"},
+				{Value: "Q", Suit: "♠"},
+				{Value: "K", Suit: "♥"},
+				{Value: "9", Suit: "♦"},
+			},
+			tripsBeatStraight: true,
+			expectedRank: HandStrength{
+				Rank:   ThreeOfAKind,
+				Values: []int{10, 13, 12},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defaultRanker := NewDefaultHandRanker()
+			smartRanker := NewSmartHandRanker()
+			defaultRanker.SetShortDeckRules(ShortDeckRules{TripsBeatStraight: tt.tripsBeatStraight})
+			smartRanker.SetShortDeckRules(ShortDeckRules{TripsBeatStraight: tt.tripsBeatStraight})
+			lookupRanker := NewLookupHandRanker()
+			lookupRanker.SetShortDeckRules(ShortDeckRules{TripsBeatStraight: tt.tripsBeatStraight})
+
+			for _, ranker := range []HandRanker{defaultRanker, smartRanker, lookupRanker} {
+				rank, cards := ranker.RankHand(Short, tt.playerCards, tt.communityCards)
+				assert.Equal(t, tt.expectedRank.Rank, rank.Rank)
+				if len(tt.expectedRank.Values) > 0 {
+					assert.Equal(t, tt.expectedRank.Values, rank.Values)
+				}
+				assert.Len(t, cards, 5)
+			}
+		})
+	}
+}
+
+func TestShortDeckCompare(t *testing.T) {
+	t.Parallel()
+
+	ranker := NewSmartHandRanker()
+	board := []*deck.Card{
+		{Value: "Q", Suit: "♥"},
+		{Value: "Q", Suit: "♠"},
+		{Value: "9", Suit: "♥"},
+		{Value: "8", Suit: "♥"},
+		{Value: "6", Suit: "♣"},
+	}
+	flush := []*deck.Card{{Value: "A", Suit: "♥"}, {Value: "7", Suit: "♥"}}
+	fullHouse := []*deck.Card{{Value: "9", Suit: "♠"}, {Value: "9", Suit: "♦"}}
+
+	flushStrength, _ := ranker.RankHand(Short, flush, board)
+	fullHouseStrength, _ := ranker.RankHand(Short, fullHouse, board)
+	assert.Equal(t, Flush, flushStrength.Rank)
+	assert.Equal(t, FullHouse, fullHouseStrength.Rank)
+	assert.Equal(t, 1, flushStrength.Compare(fullHouseStrength))
+	assert.Equal(t, []int{0}, FindWinners([]HandStrength{flushStrength, fullHouseStrength}))
+
+	texasFlush, _ := ranker.RankHand(Texas, flush, board)
+	texasFullHouse, _ := ranker.RankHand(Texas, fullHouse, board)
+	assert.Equal(t, -1, texasFlush.Compare(texasFullHouse))
+}
+
 func TestFuzzyRankerComparison(t *testing.T) {
 	t.Skip("Skipping long-running test in short mode.")
 	// Create a full deck of 52 cards
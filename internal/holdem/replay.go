@@ -0,0 +1,250 @@
+package holdem
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+
+	"github.com/genewoo/joker/internal/deck"
+)
+
+// ReplayStep is a street of a replayed hand
+type ReplayStep struct {
+	Street   Street
+	Board    []*deck.Card    // Community cards dealt so far
+	Equities []float64       // Each seat's chance to win at the start of the street, 0 once folded, followed by the chance of a tie
+	Actions  []ActionRecord  // Actions taken on the street
+	Pot      int             // Pot after the street's actions
+	Result   *ShowdownResult // Outcome of the hand, set on the last step
+}
+
+// Replayer steps through a recorded hand with a Game, street by street.
+type Replayer struct {
+	history     *HandHistory
+	game        *Game
+	simulations int
+	ranker      HandRanker
+	source      rand.Source // Source of randomness for the equity simulations
+	street      Street
+	next        int  // Index of the next action to apply
+	done        bool // Whether the showdown has been replayed
+}
+
+// ParseHandHistory parses a hand history from its JSON export.
+func ParseHandHistory(data []byte) (*HandHistory, error) {
+	var h HandHistory
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, fmt.Errorf("invalid hand history: %v", err)
+	}
+	if len(h.Seats) < 2 {
+		return nil, fmt.Errorf("hand history must have at least 2 seats, got %d", len(h.Seats))
+	}
+	return &h, nil
+}
+
+// NewReplayer creates a Replayer that deals the recorded hand again and calculates the
+// players' equities with the given number of simulations and ranker.
+// When the history has no deck order, it is rebuilt from the hole cards, burn cards
+// and board in the order they were dealt; missing burn cards are taken from the unused cards.
+// Returns an error if the history does not describe a hand the game can deal.
+func NewReplayer(history *HandHistory, simulations int, ranker HandRanker) (*Replayer, error) {
+	gameType, err := ParseGameType(history.GameType)
+	if err != nil {
+		return nil, err
+	}
+
+	game := NewGame(gameType, len(history.Seats))
+	game.Button = history.Button
+	for i, seat := range history.Seats {
+		game.Players[i].ID = seat.ID
+		game.Players[i].Chips = seat.Stack
+	}
+	if history.Betting != nil {
+		structure, err := ParseBettingStructure(history.Betting.Structure)
+		if err != nil {
+			return nil, err
+		}
+		err = game.SetBetting(BettingConfig{
+			Structure:  structure,
+			SmallBlind: history.Betting.SmallBlind,
+			BigBlind:   history.Betting.BigBlind,
+			Ante:       history.Betting.Ante,
+			RaiseCap:   history.Betting.RaiseCap,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	order, err := history.deckOrder(gameType)
+	if err != nil {
+		return nil, err
+	}
+	if err := game.StackDeck(order); err != nil {
+		return nil, err
+	}
+	if err := game.StartHand(); err != nil {
+		return nil, err
+	}
+	for i, seat := range history.Seats {
+		if dealt := asciiCards(game.Players[i].Cards); len(seat.Cards) > 0 && fmt.Sprint(dealt) != fmt.Sprint(seat.Cards) {
+			return nil, fmt.Errorf("seat %d was dealt %v from the deck, but the history shows %v", i, dealt, seat.Cards)
+		}
+	}
+
+	return &Replayer{
+		history:     history,
+		game:        game,
+		simulations: simulations,
+		ranker:      ranker,
+	}, nil
+}
+
+// SetSource sets the source of randomness for the equity simulations, so that a
+// source in the same state reproduces the same equities.
+func (r *Replayer) SetSource(src rand.Source) {
+	r.source = src
+}
+
+// Game returns the game the hand is replayed with
+func (r *Replayer) Game() *Game {
+	return r.game
+}
+
+// Next replays the next street: it deals the street's community cards, calculates the
+// equities of the players still in the hand and applies the street's actions. The step
+// that ends the hand also pays out the pots at showdown.
+// Returns io.EOF once the hand is over, or an error if a recorded action is illegal.
+func (r *Replayer) Next() (*ReplayStep, error) {
+	if r.done {
+		return nil, io.EOF
+	}
+
+	switch r.street {
+	case Flop:
+		if err := r.game.DealFlop(); err != nil {
+			return nil, err
+		}
+	case Turn, River:
+		if err := r.game.DealTurnOrRiver(); err != nil {
+			return nil, err
+		}
+	}
+
+	step := &ReplayStep{
+		Street:   r.street,
+		Board:    append([]*deck.Card{}, r.game.Community...),
+		Equities: r.equities(),
+	}
+
+	actions := r.history.Actions
+	for r.next < len(actions) && actions[r.next].Street == r.street.String() {
+		record := actions[r.next]
+		actionType, err := ParseActionType(record.Type)
+		if err != nil {
+			return nil, fmt.Errorf("action %d: %v", r.next+1, err)
+		}
+		if err := r.game.Act(Action{Player: record.Player, Type: actionType, Amount: record.Amount}); err != nil {
+			return nil, fmt.Errorf("action %d: %w", r.next+1, err)
+		}
+		step.Actions = append(step.Actions, record)
+		r.next++
+	}
+	step.Pot = r.game.Pot()
+
+	if r.street == River || r.game.activePlayers() == 1 {
+		if r.next < len(actions) {
+			return nil, fmt.Errorf("action %d is on the %s after the hand is over", r.next+1, actions[r.next].Street)
+		}
+		result, err := r.game.Showdown(r.ranker)
+		if err != nil {
+			return nil, err
+		}
+		step.Result = result
+		r.done = true
+	}
+	r.street++
+	return step, nil
+}
+
+// equities calculates each seat's chance to win with the current board
+func (r *Replayer) equities() []float64 {
+	active := r.game.activeSeats()
+	equities := make([]float64, len(r.game.Players)+1)
+	if len(active) == 1 {
+		equities[active[0]] = 1
+		return equities
+	}
+
+	players := make([][]*deck.Card, len(active))
+	for i, seat := range active {
+		players[i] = r.game.Players[seat].Cards
+	}
+	calc := NewWinningCalculator(players, r.simulations, r.ranker, r.game.Community...)
+	calc.SetGameType(r.game.gameType)
+	if r.source != nil {
+		calc.SetSource(r.source)
+	}
+	probabilities := calc.CalculateWinProbabilities()
+	for i, seat := range active {
+		equities[seat] = probabilities[i]
+	}
+	equities[len(r.game.Players)] = probabilities[len(active)]
+	return equities
+}
+
+// deckOrder returns the recorded deck order, or rebuilds the top of the deck from the
+// cards dealt: the hole cards one round at a time, then a burn card before each street.
+func (h *HandHistory) deckOrder(gameType GameType) ([]*deck.Card, error) {
+	if len(h.Deck) > 0 {
+		return parseCardList(h.Deck)
+	}
+
+	var order []string
+	for round := 0; round < gameType.HoleCards(); round++ {
+		for i, seat := range h.Seats {
+			if len(seat.Cards) != gameType.HoleCards() {
+				return nil, fmt.Errorf("seat %d must have %d hole cards, got %d", i, gameType.HoleCards(), len(seat.Cards))
+			}
+			order = append(order, seat.Cards[round])
+		}
+	}
+	boardCards := []int{0, 3, 4, 5}
+	for street := Flop; street <= River && len(h.Board) >= boardCards[street]; street++ {
+		burn := "" // Filled with an unused card below
+		if int(street) <= len(h.BurnCards) {
+			burn = h.BurnCards[street-1]
+		}
+		order = append(order, burn)
+		order = append(order, h.Board[boardCards[street-1]:boardCards[street]]...)
+	}
+
+	used := make(map[string]bool)
+	var cards []*deck.Card
+	for _, s := range order {
+		if s == "" {
+			cards = append(cards, nil)
+			continue
+		}
+		card, err := parseCard(s)
+		if err != nil {
+			return nil, err
+		}
+		used[card.String()] = true
+		cards = append(cards, card)
+	}
+
+	var unused []*deck.Card
+	for _, card := range newGameDeck(gameType).Cards {
+		if !used[card.String()] {
+			unused = append(unused, card)
+		}
+	}
+	for i := range cards {
+		if cards[i] == nil {
+			cards[i], unused = unused[0], unused[1:]
+		}
+	}
+	return append(cards, unused...), nil
+}
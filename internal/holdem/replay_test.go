@@ -0,0 +1,163 @@
+package holdem
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// replayAll steps through the whole hand
+func replayAll(t *testing.T, replayer *Replayer) []*ReplayStep {
+	t.Helper()
+	var steps []*ReplayStep
+	for {
+		step, err := replayer.Next()
+		if err == io.EOF {
+			return steps
+		}
+		if !assert.NoError(t, err) {
+			return steps
+		}
+		steps = append(steps, step)
+	}
+}
+
+func TestReplayRecordedHand(t *testing.T) {
+	game := NewGame(Texas, 3)
+	for i := range game.Players {
+		game.Players[i].Chips = 100
+	}
+	assert.NoError(t, game.SetBetting(noLimit))
+	game.SetRecording(true)
+	assert.NoError(t, game.StartHand())
+	act(t, game,
+		Action{Player: 0, Type: Raise, Amount: 6},
+		Action{Player: 1, Type: Call},
+		Action{Player: 2, Type: Call},
+	)
+	assert.NoError(t, game.DealFlop())
+	act(t, game,
+		Action{Player: 1, Type: Bet, Amount: 10},
+		Action{Player: 2, Type: Fold},
+		Action{Player: 0, Type: Call},
+	)
+	assert.NoError(t, game.DealTurnOrRiver())
+	act(t, game, Action{Player: 1, Type: Check}, Action{Player: 0, Type: Check})
+	assert.NoError(t, game.DealTurnOrRiver())
+	act(t, game, Action{Player: 1, Type: Check}, Action{Player: 0, Type: Check})
+	result, err := game.Showdown(NewSmartHandRanker())
+	assert.NoError(t, err)
+
+	data, err := game.History().JSON()
+	assert.NoError(t, err)
+	history, err := ParseHandHistory(data)
+	assert.NoError(t, err)
+	replayer, err := NewReplayer(history, 1000, NewSmartHandRanker())
+	assert.NoError(t, err)
+
+	steps := replayAll(t, replayer)
+	assert.Len(t, steps, 4)
+	for i, step := range steps {
+		assert.Equal(t, Street(i), step.Street)
+		assert.Equal(t, game.Community[:len(step.Board)], step.Board)
+
+		total := 0.0
+		for _, equity := range step.Equities {
+			total += equity
+		}
+		assert.InDelta(t, 1.0, total, 1e-9)
+	}
+	assert.Len(t, steps[0].Actions, 3)
+	assert.Equal(t, 18, steps[0].Pot)
+	assert.Equal(t, 0.0, steps[2].Equities[2]) // folded on the flop
+	assert.Equal(t, 38, steps[3].Pot)
+	assert.Equal(t, result.Payouts, steps[3].Result.Payouts)
+
+	_, err = replayer.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestReplayWithoutDeckOrder(t *testing.T) {
+	history := &HandHistory{
+		GameType:  "texas",
+		Betting:   &BettingRecord{Structure: "no-limit", SmallBlind: 1, BigBlind: 2},
+		Button:    1,
+		Seats:     []SeatRecord{{Seat: 0, Stack: 50, Cards: []string{"As", "Ah"}}, {Seat: 1, Stack: 100, Cards: []string{"Kc", "Kd"}}},
+		BurnCards: []string{"2c"},
+		Board:     []string{"Ad", "Kh", "7s", "2d", "3h"},
+		Actions: []ActionRecord{
+			{Street: "preflop", Player: 1, Type: "all-in", Amount: 100},
+			{Street: "preflop", Player: 0, Type: "call", Amount: 50},
+		},
+	}
+
+	replayer, err := NewReplayer(history, 1000, NewSmartHandRanker())
+	assert.NoError(t, err)
+	steps := replayAll(t, replayer)
+	assert.Len(t, steps, 4)
+	assert.Equal(t, history.Board, asciiCards(steps[3].Board))
+	assert.Equal(t, "2c", asciiCard(replayer.Game().burnCards[0]))
+	assert.Equal(t, []float64{1, 0, 0}, steps[3].Equities)
+	assert.Equal(t, []int{100, 0}, steps[3].Result.Payouts)
+	assert.Equal(t, 50, replayer.Game().Players[1].Chips)
+}
+
+func TestReplayFoldedHand(t *testing.T) {
+	history := &HandHistory{
+		GameType: "texas",
+		Betting:  &BettingRecord{Structure: "no-limit", SmallBlind: 1, BigBlind: 2},
+		Seats:    []SeatRecord{{Seat: 0, Stack: 100, Cards: []string{"7c", "2d"}}, {Seat: 1, Stack: 100, Cards: []string{"Kc", "Kd"}}},
+		Actions:  []ActionRecord{{Street: "preflop", Player: 0, Type: "fold"}},
+	}
+
+	replayer, err := NewReplayer(history, 1000, NewSmartHandRanker())
+	assert.NoError(t, err)
+	steps := replayAll(t, replayer)
+	assert.Len(t, steps, 1)
+	assert.Equal(t, []int{1}, steps[0].Result.Winners)
+	assert.Equal(t, []float64{0, 1, 0}, replayer.equities())
+}
+
+func TestReplayErrors(t *testing.T) {
+	seats := []SeatRecord{{Seat: 0, Stack: 100, Cards: []string{"As", "Ah"}}, {Seat: 1, Stack: 100, Cards: []string{"Kc", "Kd"}}}
+
+	tests := []struct {
+		name    string
+		history *HandHistory
+	}{
+		{name: "Unknown game type", history: &HandHistory{GameType: "stud", Seats: seats}},
+		{name: "Unknown card", history: &HandHistory{GameType: "texas", Seats: []SeatRecord{{Cards: []string{"Xs", "Ah"}}, seats[1]}}},
+		{name: "Duplicate card", history: &HandHistory{GameType: "texas", Seats: []SeatRecord{{Cards: []string{"Kc", "Ah"}}, seats[1]}}},
+		{name: "Missing hole card", history: &HandHistory{GameType: "texas", Seats: []SeatRecord{{Cards: []string{"Ah"}}, seats[1]}}},
+		{name: "Unknown structure", history: &HandHistory{GameType: "texas", Seats: seats, Betting: &BettingRecord{Structure: "spread", BigBlind: 2}}},
+		{name: "Deck does not match the hole cards", history: &HandHistory{GameType: "texas", Seats: seats, Deck: []string{"2c", "3c", "4c", "5c"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReplayer(tt.history, 100, NewSmartHandRanker())
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("Illegal action", func(t *testing.T) {
+		history := &HandHistory{
+			GameType: "texas",
+			Betting:  &BettingRecord{Structure: "no-limit", SmallBlind: 1, BigBlind: 2},
+			Seats:    seats,
+			Actions:  []ActionRecord{{Street: "preflop", Player: 0, Type: "check"}},
+		}
+		replayer, err := NewReplayer(history, 100, NewSmartHandRanker())
+		assert.NoError(t, err)
+		_, err = replayer.Next()
+		assert.ErrorIs(t, err, ErrCannotCheck)
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		_, err := ParseHandHistory([]byte("{"))
+		assert.Error(t, err)
+		_, err = ParseHandHistory([]byte(`{"game_type": "texas", "seats": []}`))
+		assert.Error(t, err)
+	})
+}
@@ -10,21 +10,28 @@ import (
 	"github.com/genewoo/joker/internal/deck"
 )
 
+// DefaultExhaustiveThreshold is the largest number of remaining boards evaluated
+// exhaustively by default. It covers every flop and turn, while pre-flop boards
+// are sampled.
+const DefaultExhaustiveThreshold = 10000
+
 // WinningCalculator calculates winning probabilities for Texas Hold'em hands
-// by simulating multiple games with random community cards and evaluating
+// by enumerating or simulating the remaining community cards and evaluating
 // the best possible hand for each player.
 type WinningCalculator struct {
-	simulations       int            // Number of simulations to run
-	players           [][]*deck.Card // Each player's hole cards
-	communityCards    []*deck.Card   // Pre-existing community cards
-	rng               *rand.Rand     // Random number generator for simulations
-	ranker            HandRanker     // Hand ranking implementation to use
-	disableGoroutines bool           // flag to disable goroutines for debugging
+	simulations         int            // Number of simulations to run
+	players             [][]*deck.Card // Each player's hole cards
+	communityCards      []*deck.Card   // Pre-existing community cards
+	rng                 *rand.Rand     // Random number generator for simulations
+	ranker              HandRanker     // Hand ranking implementation to use
+	gameType            GameType       // Game variant whose rules rank the hands
+	exhaustiveThreshold int            // Largest board count evaluated exhaustively
+	disableGoroutines   bool           // flag to disable goroutines for debugging
 }
 
 // NewWinningCalculator creates a new WinningCalculator with specified players and simulations.
 // Parameters:
-//   - players: A slice of player hole cards, where each element is a slice of 2 cards
+//   - players: A slice of player hole cards, where each element holds the game type's hole cards
 //   - simulations: Number of Monte Carlo simulations to run
 //   - ranker: The hand ranking implementation to use for evaluating hands
 //   - communityCards: Optional pre-existing community cards (0-5 cards)
@@ -33,13 +40,36 @@ func NewWinningCalculator(players [][]*deck.Card, simulations int, ranker HandRa
 		communityCards = communityCards[:5] // Allow up to 5 community cards for showdown
 	}
 	return &WinningCalculator{
-		simulations:       simulations,
-		players:           players,
-		communityCards:    communityCards,
-		rng:               rand.New(rand.NewSource(time.Now().UnixNano())),
-		ranker:            ranker,
-		disableGoroutines: true,
+		simulations:         simulations,
+		players:             players,
+		communityCards:      communityCards,
+		rng:                 rand.New(rand.NewSource(time.Now().UnixNano())),
+		ranker:              ranker,
+		gameType:            Texas,
+		exhaustiveThreshold: DefaultExhaustiveThreshold,
+		disableGoroutines:   true,
+	}
+}
+
+// SetGameType sets the game variant used to rank hands and build the deck the
+// remaining community cards are drawn from. The default is Texas; Omaha players
+// must then hold 4 hole cards each, and Short boards are drawn without 2-5.
+func (wc *WinningCalculator) SetGameType(gameType GameType) {
+	wc.gameType = gameType
+}
+
+// SetSource sets the source of randomness for the simulations, so that a source in
+// the same state reproduces the same probabilities. A nil src seeds from the current time.
+func (wc *WinningCalculator) SetSource(src rand.Source) {
+	if src == nil {
+		src = rand.NewSource(time.Now().UnixNano())
 	}
+	wc.rng = rand.New(src)
+}
+
+// Seed makes the simulations reproducible from the given seed
+func (wc *WinningCalculator) Seed(seed int64) {
+	wc.SetSource(rand.NewSource(seed))
 }
 
 // calculateRequiredSimulations determines the number of simulations needed
@@ -68,8 +98,10 @@ func min(a, b int) int {
 	return b
 }
 
-// CalculateWinProbabilities calculates winning probabilities for each player
-// by running Monte Carlo simulations with random community cards.
+// CalculateWinProbabilities calculates winning probabilities for each player.
+// When the remaining boards number no more than the exhaustive threshold, every
+// board is evaluated and the result is exact; otherwise Monte Carlo simulations
+// with random community cards are run.
 // Returns a slice of probabilities where:
 //   - indices 0 to n-1 contain each player's probability of winning
 //   - index n contains the probability of a complete tie between all players
@@ -80,12 +112,37 @@ func (wc *WinningCalculator) CalculateWinProbabilities() []float64 {
 		return nil
 	}
 
-	// Initialize results with a mutex for concurrent access
-	var mu sync.Mutex
-	results := make([]float64, len(wc.players))
-	var tieCount float64
+	d := wc.remainingDeck()
+	remainingCards := 5 - len(wc.communityCards)
+	if d.ComboCount(remainingCards) <= wc.exhaustiveThreshold {
+		return wc.enumerateBoards(d, remainingCards)
+	}
+
+	// Draw remaining community cards for each simulation
+	communityCardHands := d.DrawWithLimitHands(remainingCards, wc.calculateRequiredSimulations())
+	return wc.simulateBoards(communityCardHands)
+}
+
+// CalculateExactProbabilities calculates exact winning probabilities for each player
+// by evaluating every possible combination of the remaining community cards,
+// regardless of the exhaustive threshold.
+// The result has the same layout as CalculateWinProbabilities.
+func (wc *WinningCalculator) CalculateExactProbabilities() []float64 {
+	if len(wc.players) == 0 {
+		return nil
+	}
+	return wc.enumerateBoards(wc.remainingDeck(), 5-len(wc.communityCards))
+}
+
+// SetExhaustiveThreshold sets the largest number of remaining boards that
+// CalculateWinProbabilities evaluates exhaustively instead of sampling.
+// A threshold of 0 always samples.
+func (wc *WinningCalculator) SetExhaustiveThreshold(threshold int) {
+	wc.exhaustiveThreshold = threshold
+}
 
-	// Create a new deck with player's cards and community cards marked
+// remainingDeck builds the deck of cards not held by any player or on the board.
+func (wc *WinningCalculator) remainingDeck() *deck.Deck {
 	var markedCardsMasks []string
 	for _, hand := range wc.players {
 		for _, card := range hand {
@@ -95,102 +152,152 @@ func (wc *WinningCalculator) CalculateWinProbabilities() []float64 {
 	for _, card := range wc.communityCards {
 		markedCardsMasks = append(markedCardsMasks, card.Value+card.Suit)
 	}
-	d := deck.NewDeck(markedCardsMasks...)
+	d := newGameDeck(wc.gameType, markedCardsMasks...)
+	d.SetSource(wc.rng)
+	return d
+}
 
-	// Calculate remaining community cards needed and required simulations
-	remainingCards := 5 - len(wc.communityCards)
-	requiredSimulations := wc.calculateRequiredSimulations()
+// enumerateBoards evaluates every combination of remainingCards cards from d
+// completing the board and returns the resulting probabilities.
+func (wc *WinningCalculator) enumerateBoards(d *deck.Deck, remainingCards int) []float64 {
+	results := make([]float64, len(wc.players))
+	if remainingCards == 0 {
+		tieCount := wc.scoreBoard(wc.communityCards, results)
+		return probabilities(results, tieCount, 1)
+	}
 
-	// Draw remaining community cards for each simulation
-	communityCardHands := d.DrawWithLimitHands(remainingCards, requiredSimulations)
+	var tieCount float64
+	boards := 0
+	if wc.disableGoroutines {
+		board := append([]*deck.Card{}, wc.communityCards...)
+		d.ForEachCombination(remainingCards, func(cards []*deck.Card) {
+			board = append(board[:len(wc.communityCards)], cards...)
+			tieCount += wc.scoreBoard(board, results)
+			boards++
+		})
+		return probabilities(results, tieCount, boards)
+	}
+
+	// Feed the boards to one worker per CPU
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	boardCh := make(chan []*deck.Card, runtime.NumCPU())
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			localResults := make([]float64, len(wc.players))
+			localTieCount := 0.0
+			for board := range boardCh {
+				localTieCount += wc.scoreBoard(board, localResults)
+			}
+
+			mu.Lock()
+			for k := range results {
+				results[k] += localResults[k]
+			}
+			tieCount += localTieCount
+			mu.Unlock()
+		}()
+	}
+	d.ForEachCombination(remainingCards, func(cards []*deck.Card) {
+		board := append([]*deck.Card{}, wc.communityCards...)
+		boardCh <- append(board, cards...)
+		boards++
+	})
+	close(boardCh)
+	wg.Wait()
+
+	return probabilities(results, tieCount, boards)
+}
+
+// simulateBoards evaluates the drawn community cards appended to the existing
+// board and returns the resulting probabilities.
+func (wc *WinningCalculator) simulateBoards(communityCardHands []*deck.Hand) []float64 {
+	results := make([]float64, len(wc.players))
+	var tieCount float64
 
 	if wc.disableGoroutines {
 		// Run simulations sequentially
-		localResults := make([]float64, len(wc.players))
-		for j := 0; j < len(communityCardHands); j++ {
-			drawnCards := communityCardHands[j]
+		for _, drawnCards := range communityCardHands {
 			// Combine pre-existing community cards with drawn cards
 			allCommunityCards := append([]*deck.Card{}, wc.communityCards...)
 			allCommunityCards = append(allCommunityCards, drawnCards.Cards...)
+			tieCount += wc.scoreBoard(allCommunityCards, results)
+		}
+		return probabilities(results, tieCount, len(communityCardHands))
+	}
 
-			bestHands := make([]HandStrength, len(wc.players))
-			for k, hand := range wc.players {
-				bestHands[k], _ = wc.ranker.RankHand(Texas, hand, allCommunityCards)
+	// Original goroutine-based implementation
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	chunkSize := len(communityCardHands) / runtime.NumCPU()
+	if chunkSize == 0 {
+		chunkSize = 1
+	}
+
+	for i := 0; i < len(communityCardHands); i += chunkSize {
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			localResults := make([]float64, len(wc.players))
+			localTieCount := 0.0
+
+			for j := start; j < end && j < len(communityCardHands); j++ {
+				// Combine pre-existing community cards with drawn cards
+				allCommunityCards := append([]*deck.Card{}, wc.communityCards...)
+				allCommunityCards = append(allCommunityCards, communityCardHands[j].Cards...)
+				localTieCount += wc.scoreBoard(allCommunityCards, localResults)
 			}
 
-			winners := FindWinners(bestHands)
-			if len(winners) == 1 {
-				localResults[winners[0]] += 1.0
-			} else if len(winners) > 1 && len(winners) < len(wc.players) {
-				winnerPercentage := 1.0 / float64(len(winners))
-				for _, winner := range winners {
-					localResults[winner] += winnerPercentage
-				}
-			} else if len(winners) == len(wc.players) {
-				tieCount += 1.0
+			mu.Lock()
+			for k := range results {
+				results[k] += localResults[k]
 			}
-		}
-		for k := range results {
-			results[k] += localResults[k]
-		}
-	} else {
-		// Original goroutine-based implementation
-		var wg sync.WaitGroup
-		chunkSize := requiredSimulations / runtime.NumCPU()
-		if chunkSize == 0 {
-			chunkSize = 1
-		}
+			tieCount += localTieCount
+			mu.Unlock()
+		}(i, i+chunkSize)
+	}
+	wg.Wait()
 
-		for i := 0; i < requiredSimulations; i += chunkSize {
-			wg.Add(1)
-			go func(start, end int) {
-				defer wg.Done()
-				localResults := make([]float64, len(wc.players))
-				localTieCount := 0.0
-
-				for j := start; j < end && j < len(communityCardHands); j++ {
-					drawnCards := communityCardHands[j]
-					// Combine pre-existing community cards with drawn cards
-					allCommunityCards := append([]*deck.Card{}, wc.communityCards...)
-					allCommunityCards = append(allCommunityCards, drawnCards.Cards...)
-
-					bestHands := make([]HandStrength, len(wc.players))
-					for k, hand := range wc.players {
-						bestHands[k], _ = wc.ranker.RankHand(Texas, hand, allCommunityCards)
-					}
-
-					winners := FindWinners(bestHands)
-					if len(winners) == 1 {
-						localResults[winners[0]] += 1.0
-					} else if len(winners) > 1 && len(winners) < len(wc.players) {
-						winnerPercentage := 1.0 / float64(len(winners))
-						for _, winner := range winners {
-							localResults[winner] += winnerPercentage
-						}
-					} else if len(winners) == len(wc.players) {
-						localTieCount += 1.0
-					}
-				}
-
-				mu.Lock()
-				for k := range results {
-					results[k] += localResults[k]
-				}
-				tieCount += localTieCount
-				mu.Unlock()
-			}(i, i+chunkSize)
+	return probabilities(results, tieCount, len(communityCardHands))
+}
+
+// scoreBoard ranks every player's hand on a complete board and credits the winners in results:
+// a sole winner gets 1 and players sharing the pot split 1 between them.
+// Returns 1 when all players tie, which is counted separately from results.
+func (wc *WinningCalculator) scoreBoard(board []*deck.Card, results []float64) float64 {
+	bestHands := make([]HandStrength, len(wc.players))
+	for k, hand := range wc.players {
+		bestHands[k], _ = wc.ranker.RankHand(wc.gameType, hand, board)
+	}
+
+	winners := FindWinners(bestHands)
+	if len(winners) == 1 {
+		results[winners[0]] += 1.0
+	} else if len(winners) > 1 && len(winners) < len(wc.players) {
+		winnerPercentage := 1.0 / float64(len(winners))
+		for _, winner := range winners {
+			results[winner] += winnerPercentage
 		}
-		wg.Wait()
+	} else if len(winners) == len(wc.players) {
+		return 1.0
 	}
+	return 0
+}
 
-	// Calculate probabilities
-	probabilities := make([]float64, len(wc.players)+1) // Add extra slot for tie percentage
-	totalSimulations := float64(requiredSimulations)    // Use the calculated required simulations
+// probabilities converts win counts over the evaluated boards into probabilities,
+// with the tie probability in the extra last slot.
+func probabilities(results []float64, tieCount float64, boards int) []float64 {
+	probabilities := make([]float64, len(results)+1) // Add extra slot for tie percentage
+	if boards == 0 {
+		return probabilities
+	}
+	totalSimulations := float64(boards)
 	for i, wins := range results {
-		probabilities[i] = float64(wins) / totalSimulations
+		probabilities[i] = wins / totalSimulations
 	}
-	probabilities[len(wc.players)] = tieCount / totalSimulations // Add tie probability
-
+	probabilities[len(results)] = tieCount / totalSimulations // Add tie probability
 	return probabilities
 }
 
@@ -212,6 +319,8 @@ type ShowdownResult struct {
 	HandStrengths []HandStrength // Hand strength for each player
 	BestHands     [][]*deck.Card // The actual 5 cards making up each player's best hand
 	Winners       []int          // Indices of winning players
+	Pots          []PotResult    // Winners of the main pot and each side pot, set by Game.Showdown
+	Payouts       []int          // Chips won by each player, set by Game.Showdown
 }
 
 // EvaluateShowdown evaluates the final hands when all 5 community cards are available.
@@ -226,7 +335,7 @@ func (wc *WinningCalculator) EvaluateShowdown() (*ShowdownResult, error) {
 	bestHands := make([][]*deck.Card, len(wc.players))
 	for i, hand := range wc.players {
 		var bestHand []*deck.Card
-		handStrengths[i], bestHand = wc.ranker.RankHand(Texas, hand, wc.communityCards)
+		handStrengths[i], bestHand = wc.ranker.RankHand(wc.gameType, hand, wc.communityCards)
 		bestHands[i] = bestHand
 	}
 
@@ -80,6 +80,8 @@ func TestWinningCalculatorWithoutGoRoutine(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			calc := NewWinningCalculator(tt.players, tt.simulations, NewDefaultHandRanker())
 			calcSmart := NewWinningCalculator(tt.players, tt.simulations, NewSmartHandRanker())
+			calc.Seed(1)
+			calcSmart.Seed(1)
 			calc.disableGoroutines = true
 			calcSmart.disableGoroutines = true
 
@@ -172,6 +174,8 @@ func TestWinningCalculator(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			calc := NewWinningCalculator(tt.players, tt.simulations, NewDefaultHandRanker())
 			calcSmart := NewWinningCalculator(tt.players, tt.simulations, NewSmartHandRanker())
+			calc.Seed(1)
+			calcSmart.Seed(1)
 			calc.disableGoroutines = false
 			calcSmart.disableGoroutines = false
 			probs := calc.CalculateWinProbabilities()
@@ -289,6 +293,14 @@ func BenchmarkWinningCalculator(b *testing.B) {
 		}
 	})
 
+	b.Run("LookupHandRanker", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			calc := NewWinningCalculator(players, 1000, NewLookupHandRanker())
+			calc.disableGoroutines = false
+			_ = calc.CalculateWinProbabilities()
+		}
+	})
+
 	b.Run("DefaultHandRanker(GoRoutineOff)", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			calc := NewWinningCalculator(players, 1000, NewDefaultHandRanker())
@@ -534,3 +546,133 @@ func TestEvaluateShowdown(t *testing.T) {
 		})
 	}
 }
+
+func TestOmahaWinningCalculator(t *testing.T) {
+	t.Parallel()
+
+	players := [][]*deck.Card{
+		{deck.NewCard("A", "♠"), deck.NewCard("K", "♠"), deck.NewCard("Q", "♦"), deck.NewCard("J", "♣")},
+		{deck.NewCard("8", "♥"), deck.NewCard("8", "♦"), deck.NewCard("3", "♣"), deck.NewCard("2", "♦")},
+	}
+
+	t.Run("Showdown uses exactly two hole cards", func(t *testing.T) {
+		// Player 1 holds the only flush; player 2 holds quad eights but may only use two of them
+		community := []*deck.Card{
+			deck.NewCard("2", "♠"), deck.NewCard("5", "♠"), deck.NewCard("7", "♠"),
+			deck.NewCard("8", "♠"), deck.NewCard("8", "♣"),
+		}
+		calc := NewWinningCalculator(players, 1000, NewSmartHandRanker(), community...)
+		calc.SetGameType(Omaha)
+
+		result, err := calc.EvaluateShowdown()
+		assert.NoError(t, err)
+		assert.Equal(t, Flush, result.HandStrengths[0].Rank)
+		assert.Equal(t, FourOfAKind, result.HandStrengths[1].Rank)
+		assert.Equal(t, []int{1}, result.Winners)
+	})
+
+	t.Run("Probabilities sum to one", func(t *testing.T) {
+		calc := NewWinningCalculator(players, 2000, NewSmartHandRanker())
+		calc.SetGameType(Omaha)
+
+		probs := calc.CalculateWinProbabilities()
+		assert.Len(t, probs, len(players)+1)
+		total := 0.0
+		for _, p := range probs {
+			total += p
+		}
+		assert.InDelta(t, 1.0, total, 0.0001)
+		assert.Greater(t, probs[0], 0.0)
+		assert.Greater(t, probs[1], 0.0)
+	})
+}
+
+func TestShortDeckWinningCalculator(t *testing.T) {
+	t.Parallel()
+
+	// A-6-7-8-9 is already a straight in short deck, and no 2-5 can fall on the river
+	players := [][]*deck.Card{
+		{deck.NewCard("A", "♣"), deck.NewCard("9", "♦")},
+		{deck.NewCard("Q", "♥"), deck.NewCard("Q", "♦")},
+	}
+	community := []*deck.Card{
+		deck.NewCard("6", "♠"), deck.NewCard("7", "♥"), deck.NewCard("8", "♦"), deck.NewCard("K", "♣"),
+	}
+
+	calc := NewWinningCalculator(players, 1000, NewSmartHandRanker(), community...)
+	calc.SetGameType(Short)
+	probs := calc.CalculateWinProbabilities()
+	assert.Greater(t, probs[0], 0.0)
+	assert.Equal(t, 0.0, probs[1])
+	assert.Equal(t, 0.0, probs[2])
+
+	texasCalc := NewWinningCalculator(players, 1000, NewSmartHandRanker(), community...)
+	texasProbs := texasCalc.CalculateWinProbabilities()
+	assert.Greater(t, texasProbs[1], texasProbs[0])
+}
+
+func TestExhaustiveEnumeration(t *testing.T) {
+	t.Parallel()
+
+	players := [][]*deck.Card{
+		{deck.NewCard("A", "♥"), deck.NewCard("K", "♥")}, // Player 1 (flush draw and overcards)
+		{deck.NewCard("J", "♠"), deck.NewCard("J", "♦")}, // Player 2 (pair)
+	}
+	turn := []*deck.Card{
+		deck.NewCard("2", "♥"), deck.NewCard("5", "♥"), deck.NewCard("8", "♣"), deck.NewCard("10", "♠"),
+	}
+
+	t.Run("Turn is enumerated exactly", func(t *testing.T) {
+		// 9 hearts, 3 aces and 3 kings win for player 1 out of 44 rivers
+		calc := NewWinningCalculator(players, 10000, NewSmartHandRanker(), turn...)
+		probs := calc.CalculateWinProbabilities()
+		assert.InDelta(t, 15.0/44.0, probs[0], 1e-9)
+		assert.InDelta(t, 29.0/44.0, probs[1], 1e-9)
+		assert.Equal(t, 0.0, probs[2])
+	})
+
+	t.Run("Goroutines produce the same exact result", func(t *testing.T) {
+		calc := NewWinningCalculator(players, 10000, NewSmartHandRanker(), turn[:3]...)
+		calc.disableGoroutines = false
+		parallel := calc.CalculateWinProbabilities()
+
+		calc.disableGoroutines = true
+		sequential := calc.CalculateWinProbabilities()
+		for i := range sequential {
+			assert.InDelta(t, sequential[i], parallel[i], 1e-9)
+		}
+	})
+
+	t.Run("Exact mode ignores the threshold", func(t *testing.T) {
+		calc := NewWinningCalculator(players, 10000, NewSmartHandRanker(), turn[:3]...)
+		calc.SetExhaustiveThreshold(0)
+		exact := calc.CalculateExactProbabilities()
+
+		calc.SetExhaustiveThreshold(DefaultExhaustiveThreshold)
+		auto := calc.CalculateWinProbabilities()
+		assert.Equal(t, exact, auto)
+
+		total := 0.0
+		for _, p := range exact {
+			total += p
+		}
+		assert.InDelta(t, 1.0, total, 1e-9)
+	})
+
+	t.Run("Threshold zero samples boards", func(t *testing.T) {
+		calc := NewWinningCalculator(players, 100, NewSmartHandRanker(), turn[:3]...)
+		calc.SetExhaustiveThreshold(0)
+		probs := calc.CalculateWinProbabilities()
+		total := 0.0
+		for _, p := range probs {
+			total += p
+		}
+		assert.InDelta(t, 1.0, total, 1e-9)
+	})
+
+	t.Run("Complete board", func(t *testing.T) {
+		river := append(append([]*deck.Card{}, turn...), deck.NewCard("3", "♣"))
+		calc := NewWinningCalculator(players, 10000, NewSmartHandRanker(), river...)
+		assert.Equal(t, []float64{0, 1, 0}, calc.CalculateWinProbabilities())
+	})
+}
@@ -0,0 +1,203 @@
+package stareeyes
+
+import (
+	"sort"
+
+	"github.com/genewoo/joker/internal/deck"
+)
+
+// CombinationType represents the type of card combination
+type CombinationType int
+
+const (
+	InvalidCombination CombinationType = iota
+	Single
+	Pair
+	Straight     // 3 or more consecutive cards
+	PairStraight // 2 or more consecutive pairs
+	Bomb         // 3 or more cards of the same rank
+)
+
+// String returns the name of the combination type
+func (t CombinationType) String() string {
+	return [...]string{
+		"Invalid Combination",
+		"Single",
+		"Pair",
+		"Straight",
+		"Pair Straight",
+		"Bomb",
+	}[t]
+}
+
+// Ranks of the card values: 3 is the lowest card and 2 the highest
+var valueRanks = map[string]int{
+	"3": 3, "4": 4, "5": 5, "6": 6, "7": 7, "8": 8, "9": 9,
+	"10": 10, "J": 11, "Q": 12, "K": 13, "A": 14, "2": 15,
+}
+
+const (
+	lowestRank = 3
+	aceRank    = 14
+	twoRank    = 15
+)
+
+// Combination is what a set of cards plays as
+type Combination struct {
+	Type   CombinationType
+	Rank   int // Rank of the highest card, from 3 to 15 for the 2
+	Length int // Number of cards
+}
+
+// Rank returns the rank of a card from 3 to 15 for the 2, or 0 for a joker
+func Rank(card *deck.Card) int {
+	return valueRanks[card.Value]
+}
+
+// IsWild reports whether the card is wild: a joker, which stands in for any other card
+func IsWild(card *deck.Card) bool {
+	return card.Value == "Joker"
+}
+
+// Evaluate returns every combination the cards can play as, with the jokers standing in
+// for any card, the strongest first. Returns nil if they form no combination.
+func Evaluate(cards []*deck.Card) []Combination {
+	ranks := make([]int, 0, len(cards))
+	wilds := 0
+	for _, card := range cards {
+		if IsWild(card) {
+			wilds++
+		} else {
+			ranks = append(ranks, Rank(card))
+		}
+	}
+
+	seen := make(map[Combination]bool)
+	var combinations []Combination
+	var substitute func(ranks []int, wilds int)
+	substitute = func(ranks []int, wilds int) {
+		if wilds == 0 {
+			if c := classify(ranks); c.Type != InvalidCombination && !seen[c] {
+				seen[c] = true
+				combinations = append(combinations, c)
+			}
+			return
+		}
+		for rank := lowestRank; rank <= twoRank; rank++ {
+			substitute(append(ranks, rank), wilds-1)
+		}
+	}
+	substitute(ranks, wilds)
+
+	sort.SliceStable(combinations, func(i, j int) bool {
+		return stronger(combinations[i], combinations[j])
+	})
+	return combinations
+}
+
+// classify determines the combination of cards with the given ranks
+func classify(ranks []int) Combination {
+	n := len(ranks)
+	if n == 0 {
+		return Combination{}
+	}
+	sorted := append([]int{}, ranks...)
+	sort.Ints(sorted)
+	top := sorted[n-1]
+
+	switch {
+	case n == 1:
+		return Combination{Type: Single, Rank: top, Length: n}
+	case sorted[0] == top && n == 2:
+		return Combination{Type: Pair, Rank: top, Length: n}
+	case sorted[0] == top:
+		return Combination{Type: Bomb, Rank: top, Length: n}
+	case n >= 3 && consecutive(sorted, 1):
+		return Combination{Type: Straight, Rank: top, Length: n}
+	case n >= 4 && n%2 == 0 && consecutive(sorted, 2):
+		return Combination{Type: PairStraight, Rank: top, Length: n}
+	}
+	return Combination{}
+}
+
+// consecutive reports whether the sorted ranks are groups of width equal ranks, each one
+// above the last and up to the A: the 2 is never part of a straight
+func consecutive(sorted []int, width int) bool {
+	if sorted[len(sorted)-1] > aceRank {
+		return false
+	}
+	for i := range sorted {
+		if sorted[i] != sorted[0]+i/width {
+			return false
+		}
+	}
+	return true
+}
+
+// Beats reports whether the next combination beats the previous one:
+//   - a bomb beats every other combination, and a bomb of more cards, or of as many
+//     cards and a higher rank, beats another bomb
+//   - otherwise both must be of the same type and number of cards, and next must rank
+//     exactly one above prev, except that a single or pair of 2 beats any other single or pair
+func Beats(prev, next Combination) bool {
+	if prev.Type == InvalidCombination || next.Type == InvalidCombination {
+		return false
+	}
+	if next.Type == Bomb || prev.Type == Bomb {
+		if next.Type != Bomb || prev.Type != Bomb {
+			return next.Type == Bomb
+		}
+		if next.Length != prev.Length {
+			return next.Length > prev.Length
+		}
+		return next.Rank > prev.Rank
+	}
+	if next.Type != prev.Type || next.Length != prev.Length {
+		return false
+	}
+	if next.Rank == prev.Rank+1 {
+		return true
+	}
+	return (next.Type == Single || next.Type == Pair) && next.Rank == twoRank && prev.Rank != twoRank
+}
+
+// stronger reports whether combination a is stronger than b: bombs first, then by
+// number of cards and rank
+func stronger(a, b Combination) bool {
+	if (a.Type == Bomb) != (b.Type == Bomb) {
+		return a.Type == Bomb
+	}
+	if a.Length != b.Length {
+		return a.Length > b.Length
+	}
+	if a.Rank != b.Rank {
+		return a.Rank > b.Rank
+	}
+	return a.Type > b.Type
+}
+
+// Organizer sorts cards from the highest: the jokers, then 2, A and down to 3.
+// Cards of the same rank are sorted by suit as deck.DefaultOrganizer does.
+type Organizer struct{}
+
+// suitOrder orders the suits of cards of the same rank
+var suitOrder = map[string]int{"Red": 6, "BW": 5, "♠": 4, "♥": 3, "♦": 2, "♣": 1}
+
+// Sort implements the deck.Organizer interface
+func (Organizer) Sort(cards []*deck.Card) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		ri, rj := sortRank(cards[i]), sortRank(cards[j])
+		if ri != rj {
+			return ri > rj
+		}
+		return suitOrder[cards[i].Suit] > suitOrder[cards[j].Suit]
+	})
+}
+
+// sortRank ranks the jokers above the 2 for sorting
+func sortRank(card *deck.Card) int {
+	if IsWild(card) {
+		return twoRank + 1
+	}
+	return Rank(card)
+}
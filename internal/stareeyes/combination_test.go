@@ -0,0 +1,103 @@
+package stareeyes
+
+import (
+	"testing"
+
+	"github.com/genewoo/joker/internal/deck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type CombinationTestSuite struct {
+	suite.Suite
+}
+
+func TestCombinationSuite(t *testing.T) {
+	suite.Run(t, new(CombinationTestSuite))
+}
+
+func (suite *CombinationTestSuite) evaluate(notation string) []Combination {
+	cards, err := deck.ParseCards(notation)
+	suite.Require().NoError(err)
+	return Evaluate(cards)
+}
+
+func (suite *CombinationTestSuite) TestEvaluate() {
+	tests := []struct {
+		name     string
+		cards    string
+		expected Combination
+	}{
+		{name: "Single", cards: "9s", expected: Combination{Type: Single, Rank: 9, Length: 1}},
+		{name: "2 is the highest single", cards: "2h", expected: Combination{Type: Single, Rank: twoRank, Length: 1}},
+		{name: "Pair", cards: "Qs Qd", expected: Combination{Type: Pair, Rank: 12, Length: 2}},
+		{name: "Straight", cards: "5s 3d 4c", expected: Combination{Type: Straight, Rank: 5, Length: 3}},
+		{name: "Straight up to A", cards: "10s Jd Qc Kh As", expected: Combination{Type: Straight, Rank: aceRank, Length: 5}},
+		{name: "Pair straight", cards: "7s 7d 8c 8h", expected: Combination{Type: PairStraight, Rank: 8, Length: 4}},
+		{name: "Bomb of three", cards: "4s 4d 4c", expected: Combination{Type: Bomb, Rank: 4, Length: 3}},
+		{name: "Bomb of four", cards: "Ks Kd Kc Kh", expected: Combination{Type: Bomb, Rank: 13, Length: 4}},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			assert.Equal(suite.T(), []Combination{tt.expected}, suite.evaluate(tt.cards))
+		})
+	}
+}
+
+func (suite *CombinationTestSuite) TestInvalid() {
+	for _, cards := range []string{"3s 5d", "3s 4d", "Ks As 2d", "3s 3d 4c", "7s 7d 8c 9h", "3s 3d 4c 4h 6s 6d"} {
+		assert.Empty(suite.T(), suite.evaluate(cards), cards)
+	}
+	assert.Empty(suite.T(), Evaluate(nil))
+}
+
+func (suite *CombinationTestSuite) TestJokers() {
+	combinations := suite.evaluate("RJ")
+	suite.Require().Len(combinations, 13)
+	assert.Equal(suite.T(), Combination{Type: Single, Rank: twoRank, Length: 1}, combinations[0], "The strongest first")
+
+	assert.Equal(suite.T(), []Combination{{Type: Pair, Rank: 9, Length: 2}}, suite.evaluate("9s BJ"))
+	assert.Contains(suite.T(), suite.evaluate("5s BJ 7d"), Combination{Type: Straight, Rank: 7, Length: 3})
+	assert.Equal(suite.T(), Combination{Type: Bomb, Rank: 6, Length: 4}, suite.evaluate("6s 6d RJ BJ")[0])
+	assert.Contains(suite.T(), suite.evaluate("6s 6d RJ BJ"), Combination{Type: PairStraight, Rank: 7, Length: 4})
+}
+
+func (suite *CombinationTestSuite) TestBeats() {
+	tests := []struct {
+		name     string
+		prev     Combination
+		next     Combination
+		expected bool
+	}{
+		{name: "One above", prev: Combination{Single, 5, 1}, next: Combination{Single, 6, 1}, expected: true},
+		{name: "Two above", prev: Combination{Single, 5, 1}, next: Combination{Single, 7, 1}, expected: false},
+		{name: "Lower", prev: Combination{Single, 5, 1}, next: Combination{Single, 4, 1}, expected: false},
+		{name: "2 beats any single", prev: Combination{Single, 5, 1}, next: Combination{Single, twoRank, 1}, expected: true},
+		{name: "2 beats A", prev: Combination{Single, aceRank, 1}, next: Combination{Single, twoRank, 1}, expected: true},
+		{name: "2 does not beat 2", prev: Combination{Single, twoRank, 1}, next: Combination{Single, twoRank, 1}, expected: false},
+		{name: "Pair of 2 beats any pair", prev: Combination{Pair, 9, 2}, next: Combination{Pair, twoRank, 2}, expected: true},
+		{name: "Straight one above", prev: Combination{Straight, 7, 3}, next: Combination{Straight, 8, 3}, expected: true},
+		{name: "Straight of another length", prev: Combination{Straight, 7, 3}, next: Combination{Straight, 8, 4}, expected: false},
+		{name: "Pair straight one above", prev: Combination{PairStraight, 7, 4}, next: Combination{PairStraight, 8, 4}, expected: true},
+		{name: "Different types", prev: Combination{Single, 7, 1}, next: Combination{Pair, 8, 2}, expected: false},
+		{name: "Bomb beats a straight", prev: Combination{Straight, aceRank, 5}, next: Combination{Bomb, 3, 3}, expected: true},
+		{name: "Higher bomb", prev: Combination{Bomb, 9, 3}, next: Combination{Bomb, 3, 4}, expected: true},
+		{name: "Bomb of the same length and higher rank", prev: Combination{Bomb, 9, 3}, next: Combination{Bomb, 12, 3}, expected: true},
+		{name: "Nothing else beats a bomb", prev: Combination{Bomb, 3, 3}, next: Combination{Single, twoRank, 1}, expected: false},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			assert.Equal(suite.T(), tt.expected, Beats(tt.prev, tt.next))
+		})
+	}
+}
+
+func (suite *CombinationTestSuite) TestOrganizer() {
+	cards, err := deck.ParseCards("3s As RJ 2d 10h BJ 10s")
+	suite.Require().NoError(err)
+	hand := deck.NewHand(cards...)
+	hand.SetOrganizer(Organizer{})
+	assert.Equal(suite.T(), "JokerRed,JokerBW,2♦,A♠,10♠,10♥,3♠", hand.String())
+}
@@ -0,0 +1,257 @@
+package stareeyes
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/genewoo/joker/internal/dealer"
+	"github.com/genewoo/joker/internal/deck"
+)
+
+// The players: A won the last game, so they are dealt 6 cards and lead the first round
+const (
+	PlayerA = 1
+	PlayerB = 2
+)
+
+// Errors returned when a play or pass is not allowed
+var (
+	ErrNotDealt           = errors.New("the cards have not been dealt")
+	ErrGameOver           = errors.New("the game is over")
+	ErrNotYourTurn        = errors.New("it is not the player's turn")
+	ErrInvalidCombination = errors.New("cards do not form a valid combination")
+	ErrDoesNotBeat        = errors.New("combination does not beat the current round")
+	ErrCannotPass         = errors.New("the leader of a round cannot pass")
+	ErrCardsNotInHand     = errors.New("cards are not in the player's hand")
+)
+
+// Game represents a game of Stare-Eyes (瞪眼) between players A and B
+type Game struct {
+	hands  [2]*deck.Hand
+	stock  []*deck.Card // Cards left to draw, top first
+	dealer dealer.DealStrategy
+	source rand.Source // Source of randomness for shuffling; nil seeds from the current time
+
+	turn   int   // Player to play, 0 before the deal and once the game is over
+	round  *Play // Play to beat in the current round, nil when a new round is led
+	plays  []Play
+	winner int
+}
+
+// Play is a player's turn in a round: the cards played, or a pass when there are none
+type Play struct {
+	Player      int
+	Cards       []*deck.Card
+	Combination Combination
+}
+
+// IsPass reports whether the player passed
+func (p Play) IsPass() bool {
+	return len(p.Cards) == 0
+}
+
+// NewGame creates a new game
+func NewGame() *Game {
+	return &Game{dealer: &dealer.StandardDealer{}}
+}
+
+// newGameWithHands creates a game with pre-defined hands and stock for testing, with A to lead
+func newGameWithHands(a, b *deck.Hand, stock []*deck.Card) *Game {
+	g := NewGame()
+	g.hands = [2]*deck.Hand{a, b}
+	for _, hand := range g.hands {
+		hand.SetOrganizer(Organizer{})
+	}
+	g.stock = stock
+	g.turn = PlayerA
+	return g
+}
+
+// SetSource sets the source of randomness used to shuffle the deck, so that a
+// source in the same state deals the same hands
+func (g *Game) SetSource(src rand.Source) {
+	g.source = src
+}
+
+// Seed makes the hands dealt reproducible from the given seed
+func (g *Game) Seed(seed int64) {
+	g.SetSource(rand.NewSource(seed))
+}
+
+// Deal shuffles a deck of 54 cards and deals them one by one to A and B until A has
+// 6 cards and B has 5. The rest of the deck is the stock, and A leads the first round.
+func (g *Game) Deal() error {
+	d := deck.NewDeckWithJokers()
+	d.SetSource(g.source)
+	d.Shuffle()
+
+	hands, err := g.dealer.Deal(d, 5, 2)
+	if err != nil {
+		return err
+	}
+	hands[0].AddCard(d.Cards[0])
+	d.Cards = d.Cards[1:]
+	for i, hand := range hands {
+		hand.SetOrganizer(Organizer{})
+		hand.Sort()
+		g.hands[i] = hand
+	}
+
+	g.stock = d.Cards
+	g.turn = PlayerA
+	g.round = nil
+	g.plays = nil
+	g.winner = 0
+	return nil
+}
+
+// Hand returns the hand of the player
+func (g *Game) Hand(player int) *deck.Hand {
+	return g.hands[player-1]
+}
+
+// Stock returns the number of cards left to draw
+func (g *Game) Stock() int {
+	return len(g.stock)
+}
+
+// Turn returns the player to play, or 0 if the cards have not been dealt or the game is over
+func (g *Game) Turn() int {
+	return g.turn
+}
+
+// Round returns the play to beat in the current round, or nil if the player to play leads a new round
+func (g *Game) Round() *Play {
+	return g.round
+}
+
+// Plays returns every play and pass of the game so far, in order
+func (g *Game) Plays() []Play {
+	return g.plays
+}
+
+// Winner returns the player who won the game by playing their last card, or 0 while it is played
+func (g *Game) Winner() int {
+	return g.winner
+}
+
+// Play plays the cards from the player's hand. The leader of a round may play any
+// combination; jokers then play as the strongest combination they can form. The other
+// player must beat the round's last play, and jokers play as the lowest combination
+// that does. A player who plays their last card wins the game.
+// Returns an error if the play is not allowed; the game is unchanged then.
+func (g *Game) Play(player int, cards []*deck.Card) error {
+	if err := g.checkTurn(player); err != nil {
+		return err
+	}
+
+	combinations := Evaluate(cards)
+	if len(combinations) == 0 {
+		return ErrInvalidCombination
+	}
+	combination := combinations[0]
+	if g.round != nil {
+		found := false
+		for i := len(combinations) - 1; i >= 0 && !found; i-- {
+			if Beats(g.round.Combination, combinations[i]) {
+				combination, found = combinations[i], true
+			}
+		}
+		if !found {
+			return ErrDoesNotBeat
+		}
+	}
+
+	hand := g.Hand(player)
+	played, err := takeCards(hand, cards)
+	if err != nil {
+		return err
+	}
+
+	play := Play{Player: player, Cards: played, Combination: combination}
+	g.plays = append(g.plays, play)
+	g.round = &play
+	if hand.Count() == 0 {
+		g.winner = player
+		g.turn = 0
+		return nil
+	}
+	g.turn = other(player)
+	return nil
+}
+
+// Pass passes the player's turn, which ends the round: the player who made the last
+// play draws a card from the stock, then the player who passed, and the winner of the
+// round leads the next one. No cards are drawn once the stock is empty.
+func (g *Game) Pass(player int) error {
+	if err := g.checkTurn(player); err != nil {
+		return err
+	}
+	if g.round == nil {
+		return ErrCannotPass
+	}
+
+	g.plays = append(g.plays, Play{Player: player})
+	leader := g.round.Player
+	for _, p := range []int{leader, player} {
+		if len(g.stock) > 0 {
+			g.Hand(p).AddCard(g.stock[0])
+			g.Hand(p).Sort()
+			g.stock = g.stock[1:]
+		}
+	}
+	g.round = nil
+	g.turn = leader
+	return nil
+}
+
+func (g *Game) checkTurn(player int) error {
+	if g.winner != 0 {
+		return ErrGameOver
+	}
+	if g.turn == 0 {
+		return ErrNotDealt
+	}
+	if player != g.turn {
+		return ErrNotYourTurn
+	}
+	return nil
+}
+
+// other returns the other player
+func other(player int) int {
+	return 3 - player
+}
+
+// takeCards removes the cards matching the given ones by value and suit from the hand,
+// each hand card used once, and returns them. Returns ErrCardsNotInHand, leaving the
+// hand unchanged, if any is missing.
+func takeCards(hand *deck.Hand, cards []*deck.Card) ([]*deck.Card, error) {
+	used := make(map[int]bool, len(cards))
+	for _, card := range cards {
+		found := false
+		for i, c := range hand.Cards {
+			if !used[i] && c.Value == card.Value && c.Suit == card.Suit {
+				used[i], found = true, true
+				break
+			}
+		}
+		if !found {
+			return nil, ErrCardsNotInHand
+		}
+	}
+
+	var taken, kept []*deck.Card
+	for i, c := range hand.Cards {
+		if used[i] {
+			taken = append(taken, c)
+		} else {
+			kept = append(kept, c)
+		}
+	}
+	hand.Cards = kept
+	if hand.Cards == nil {
+		hand.Cards = []*deck.Card{}
+	}
+	return taken, nil
+}
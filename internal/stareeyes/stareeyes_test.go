@@ -0,0 +1,98 @@
+package stareeyes
+
+import (
+	"testing"
+
+	"github.com/genewoo/joker/internal/deck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GameTestSuite struct {
+	suite.Suite
+}
+
+func TestGameSuite(t *testing.T) {
+	suite.Run(t, new(GameTestSuite))
+}
+
+func (suite *GameTestSuite) cards(notation string) []*deck.Card {
+	cards, err := deck.ParseCards(notation)
+	suite.Require().NoError(err)
+	return cards
+}
+
+// newGame creates a game with the hands of A and B and the stock, A to lead
+func (suite *GameTestSuite) newGame(a, b, stock string) *Game {
+	return newGameWithHands(deck.NewHand(suite.cards(a)...), deck.NewHand(suite.cards(b)...), suite.cards(stock))
+}
+
+func (suite *GameTestSuite) play(game *Game, player int, notation string) {
+	suite.Require().NoError(game.Play(player, suite.cards(notation)), "player %d plays %s", player, notation)
+}
+
+func (suite *GameTestSuite) TestDeal() {
+	game := NewGame()
+	assert.ErrorIs(suite.T(), game.Pass(PlayerA), ErrNotDealt)
+
+	game.Seed(42)
+	suite.Require().NoError(game.Deal())
+	assert.Equal(suite.T(), 6, game.Hand(PlayerA).Count())
+	assert.Equal(suite.T(), 5, game.Hand(PlayerB).Count())
+	assert.Equal(suite.T(), 43, game.Stock())
+	assert.Equal(suite.T(), PlayerA, game.Turn())
+
+	again := NewGame()
+	again.Seed(42)
+	suite.Require().NoError(again.Deal())
+	assert.Equal(suite.T(), game.Hand(PlayerA).String(), again.Hand(PlayerA).String(), "The same seed deals the same hands")
+	assert.Equal(suite.T(), game.Hand(PlayerB).String(), again.Hand(PlayerB).String())
+}
+
+func (suite *GameTestSuite) TestRoundAndDraw() {
+	game := suite.newGame("5s 9d 9c Kh", "6s 3d 8h", "Qs Jd 4c")
+
+	suite.play(game, PlayerA, "5s")
+	suite.play(game, PlayerB, "6s")
+	assert.ErrorIs(suite.T(), game.Play(PlayerA, suite.cards("9d")), ErrDoesNotBeat, "Only a 7 or a 2 beats a 6")
+	suite.Require().NoError(game.Pass(PlayerA))
+
+	// B won the round: B draws first and leads the next one
+	assert.Equal(suite.T(), PlayerB, game.Turn())
+	assert.Nil(suite.T(), game.Round())
+	assert.Equal(suite.T(), "Q♠,8♥,3♦", game.Hand(PlayerB).String())
+	assert.Equal(suite.T(), "K♥,J♦,9♦,9♣", game.Hand(PlayerA).String())
+	assert.Equal(suite.T(), 1, game.Stock())
+
+	assert.ErrorIs(suite.T(), game.Pass(PlayerB), ErrCannotPass)
+	suite.play(game, PlayerB, "Qs")
+	suite.play(game, PlayerA, "Kh")
+	suite.Require().NoError(game.Pass(PlayerB))
+
+	// Only one card was left to draw
+	assert.Equal(suite.T(), 0, game.Stock())
+	assert.Equal(suite.T(), 4, game.Hand(PlayerA).Count())
+	assert.Equal(suite.T(), 2, game.Hand(PlayerB).Count())
+}
+
+func (suite *GameTestSuite) TestJokersBeatAsTheLowestCombination() {
+	game := suite.newGame("7s 7d Ks", "RJ 8s 3c", "")
+
+	suite.play(game, PlayerA, "7s 7d")
+	suite.play(game, PlayerB, "RJ 8s")
+	assert.Equal(suite.T(), Combination{Type: Pair, Rank: 8, Length: 2}, game.Round().Combination)
+}
+
+func (suite *GameTestSuite) TestWinner() {
+	game := suite.newGame("4s 4d 4c", "2s 5d", "")
+
+	assert.ErrorIs(suite.T(), game.Play(PlayerB, suite.cards("2s")), ErrNotYourTurn)
+	assert.ErrorIs(suite.T(), game.Play(PlayerA, suite.cards("4s 5d")), ErrInvalidCombination)
+	assert.ErrorIs(suite.T(), game.Play(PlayerA, suite.cards("4s 4h")), ErrCardsNotInHand)
+
+	suite.play(game, PlayerA, "4s 4d 4c")
+	assert.Equal(suite.T(), PlayerA, game.Winner())
+	assert.Equal(suite.T(), 0, game.Turn())
+	assert.ErrorIs(suite.T(), game.Pass(PlayerB), ErrGameOver)
+	assert.Len(suite.T(), game.Plays(), 1)
+}